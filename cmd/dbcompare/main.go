@@ -10,11 +10,14 @@ import (
 	"github.com/nadmax/dbcompare/internal/benchmarks"
 	"github.com/nadmax/dbcompare/internal/config"
 	"github.com/nadmax/dbcompare/internal/reporter"
+	"github.com/nadmax/dbcompare/internal/workload"
 )
 
 func main() {
 	configPath := flag.String("config", "configs/config.yml", "Path to configuration file")
-	dbFilter := flag.String("db", "", "Run only specific database (postgres, oracle, surrealdb)")
+	dbFilter := flag.String("db", "", "Run only specific database (postgres, mysql, oracle, sqlite, surrealdb)")
+	suiteName := flag.String("suite", "catalog", "Benchmark suite to run (catalog, ycsb, tpcb, tpcc, tables, documents)")
+	workloadPath := flag.String("workload", "", "Path to a workload file replacing the catalog suite, or run on the tables suite's tables")
 	flag.Parse()
 
 	cfg, err := config.Load(*configPath)
@@ -29,6 +32,18 @@ func main() {
 
 	reporters := createReporters(cfg)
 	runner := benchmarks.NewRunner(cfg)
+	if err := runner.UseSuite(*suiteName); err != nil {
+		log.Fatalf("Failed to select suite: %v", err)
+	}
+	if *workloadPath != "" {
+		wl, err := workload.Load(*workloadPath)
+		if err != nil {
+			log.Fatalf("Failed to load workload: %v", err)
+		}
+		if err := runner.UseWorkload(wl); err != nil {
+			log.Fatalf("Failed to select workload: %v", err)
+		}
+	}
 	results, err := runner.Run(*dbFilter)
 	if err != nil {
 		log.Fatalf("Benchmark execution failed: %v", err)
@@ -48,7 +63,7 @@ func createReporters(cfg *config.Config) []reporter.Reporter {
 	for _, format := range cfg.Output.Format {
 		switch format {
 		case "console":
-			reporters = append(reporters, reporter.NewConsoleReporter())
+			reporters = append(reporters, reporter.NewConsoleReporter(cfg.Benchmark.SignificanceTest, cfg.Benchmark.SignificanceLevel))
 		case "csv":
 			filename := fmt.Sprintf("%s/%s_%s.csv",
 				cfg.Output.Directory,
@@ -3,11 +3,15 @@ package benchmarks
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/nadmax/dbcompare/internal/config"
 	"github.com/nadmax/dbcompare/internal/database"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/metrics"
 	"github.com/nadmax/dbcompare/internal/models"
+	"github.com/nadmax/dbcompare/internal/workload"
 )
 
 type Benchmark interface {
@@ -19,7 +23,10 @@ type Benchmark interface {
 
 type Runner struct {
 	config     *config.Config
+	names      []string
 	benchmarks map[string]Benchmark
+	suite      string
+	workload   *workload.Workload
 }
 
 func NewRunner(cfg *config.Config) *Runner {
@@ -27,35 +34,107 @@ func NewRunner(cfg *config.Config) *Runner {
 		config:     cfg,
 		benchmarks: make(map[string]Benchmark),
 	}
-	if cfg.Databases.Postgres.Enabled {
-		pgDB, err := database.NewPostgresDB(&cfg.Databases.Postgres)
-		if err != nil {
-			log.Printf("Warning: Failed to initialize PostgreSQL: %v", err)
-		} else {
-			runner.benchmarks["postgres"] = NewPostgresBenchmark(pgDB, cfg)
+
+	for _, reg := range database.Registered() {
+		if !reg.Enabled(cfg) {
+			continue
 		}
-	}
 
-	if cfg.Databases.SurrealDB.Enabled {
-		surrealDB, err := database.NewSurrealDB(&cfg.Databases.SurrealDB)
+		driver, err := reg.Open(cfg)
 		if err != nil {
-			log.Printf("Warning: Failed to initialize SurrealDB: %v", err)
-		} else {
-			runner.benchmarks["surrealdb"] = NewSurrealDBBenchmark(surrealDB, cfg)
+			log.Printf("Warning: Failed to initialize %s: %v", reg.Name, err)
+			continue
 		}
+		runner.names = append(runner.names, reg.Name)
+		runner.benchmarks[reg.Name] = NewDriverBenchmark(driver, cfg)
 	}
 
 	return runner
 }
 
+const (
+	SuiteCatalog   = "catalog"
+	SuiteYCSB      = "ycsb"
+	SuiteTPCB      = "tpcb"
+	SuiteTPCC      = "tpcc"
+	SuiteTables    = "tables"
+	SuiteDocuments = "documents"
+)
+
+// UseSuite replaces the built-in catalog of every enabled database with one of
+// the standard benchmark suites.
+func (r *Runner) UseSuite(suite string) error {
+	switch suite {
+	case "", SuiteCatalog:
+		return nil
+	case SuiteYCSB:
+		r.wrap(func(d *DriverBenchmark, _ string) Benchmark { return NewYCSBBenchmark(d) })
+	case SuiteTPCB:
+		r.wrap(func(d *DriverBenchmark, _ string) Benchmark { return NewTPCBBenchmark(d) })
+	case SuiteTPCC:
+		r.wrap(func(d *DriverBenchmark, _ string) Benchmark { return NewTPCCBenchmark(d) })
+	case SuiteTables:
+		r.wrap(func(d *DriverBenchmark, _ string) Benchmark { return NewTablesBenchmark(d) })
+	case SuiteDocuments:
+		r.wrap(func(d *DriverBenchmark, _ string) Benchmark { return NewDocumentsBenchmark(d) })
+	default:
+		return fmt.Errorf("unknown suite %q (expected %s, %s, %s, %s, %s or %s)", suite, SuiteCatalog, SuiteYCSB, SuiteTPCB, SuiteTPCC, SuiteTables, SuiteDocuments)
+	}
+
+	r.suite = suite
+	return nil
+}
+
+// UseWorkload replaces the built-in catalog of every enabled database with the
+// operations of a workload file. With the tables suite, the workload runs on
+// the custom tables once they are loaded.
+func (r *Runner) UseWorkload(wl *workload.Workload) error {
+	if r.suite != "" && r.suite != SuiteTables {
+		return fmt.Errorf("a workload replaces the catalog and cannot run with the %s suite", r.suite)
+	}
+	if err := wl.CheckEngines(r.names); err != nil {
+		return err
+	}
+
+	r.workload = wl
+	for _, name := range r.names {
+		switch b := r.benchmarks[name].(type) {
+		case *DriverBenchmark:
+			r.benchmarks[name] = NewWorkloadBenchmark(b, name, wl)
+		case *TablesBenchmark:
+			w := NewWorkloadBenchmark(b.DriverBenchmark, name, wl)
+			w.tables = b
+			r.benchmarks[name] = w
+		}
+	}
+	return nil
+}
+
+func (r *Runner) wrap(fn func(d *DriverBenchmark, engine string) Benchmark) {
+	for _, name := range r.names {
+		if d, ok := r.benchmarks[name].(*DriverBenchmark); ok {
+			r.benchmarks[name] = fn(d, name)
+		}
+	}
+}
+
 func (r *Runner) Run(filter string) (*models.BenchmarkSuite, error) {
 	suite := &models.BenchmarkSuite{
 		Results: make([]models.BenchmarkResult, 0),
 		Config:  make(map[string]any),
 	}
 	suite.StartTime = time.Now()
+	suite.Config["seed"] = r.config.Benchmark.Seed
+	if r.suite != "" {
+		suite.Config["suite"] = r.suite
+	}
+	if r.workload != nil {
+		suite.Config["workload"] = r.workload.Name
+	}
+	fmt.Printf("Seed: %d\n", r.config.Benchmark.Seed)
 
-	for name, bench := range r.benchmarks {
+	for _, name := range r.names {
+		bench := r.benchmarks[name]
 		if filter != "" && filter != name {
 			continue
 		}
@@ -79,6 +158,12 @@ func (r *Runner) Run(filter string) (*models.BenchmarkSuite, error) {
 		}
 	}
 
+	suite.Scaling = models.BuildScalingCurves(suite.Results)
+	suite.Parity = models.FindParityGaps(suite.Results)
+	for _, gap := range suite.Parity {
+		fmt.Printf("Warning: %s ran on %s only (missing on %s)\n",
+			gap.Operation, strings.Join(gap.Ran, ", "), strings.Join(gap.Missing, ", "))
+	}
 	suite.EndTime = time.Now()
 	suite.Duration = suite.EndTime.Sub(suite.StartTime)
 
@@ -88,12 +173,82 @@ func (r *Runner) Run(filter string) (*models.BenchmarkSuite, error) {
 type BaseBenchmark struct {
 	name   string
 	config *config.Config
+	// seeds holds the streams derived from benchmark.seed; streams are those
+	// of the pass measure is running, or seeds outside of measure.
+	seeds   *generator.Streams
+	streams *generator.Streams
 }
 
 func (b *BaseBenchmark) Name() string {
 	return b.name
 }
 
+// generators returns one generator per worker of an operation; worker w must
+// only use gens[w]. Their seeds derive from benchmark.seed, the warmup or
+// iteration being measured, the operation and the worker but not from the
+// engine, so every engine sees the same records and key sequences.
+func (b *BaseBenchmark) generators(operation string, workers int) []*generator.Generator {
+	return b.streams.Workers(operation, workers)
+}
+
+func (b *BaseBenchmark) generator(operation string) *generator.Generator {
+	return b.streams.Generator(operation, 0)
+}
+
+// measure runs op through the configured warmup iterations, whose results are
+// discarded, and then the measured iterations, which are aggregated into one
+// result. reset, when set, restores the starting state before every run.
+func (b *BaseBenchmark) measure(op func() (*models.BenchmarkResult, error), reset func() error) (*models.BenchmarkResult, error) {
+	warmup := b.config.Benchmark.WarmupIterations
+	iterations := b.config.Benchmark.Iterations
+	defer func() { b.streams = b.seeds }()
+
+	for i := range warmup {
+		b.streams = b.seeds.Pass("warmup", i)
+		if reset != nil {
+			if err := reset(); err != nil {
+				return nil, fmt.Errorf("reset before warmup failed: %w", err)
+			}
+		}
+		fmt.Printf("[warmup %d/%d] ", i+1, warmup)
+		if _, err := op(); err != nil {
+			return nil, fmt.Errorf("warmup failed: %w", err)
+		}
+	}
+
+	results := make([]*models.BenchmarkResult, 0, iterations)
+	for i := range iterations {
+		b.streams = b.seeds.Pass("iteration", i)
+		if reset != nil {
+			if err := reset(); err != nil {
+				return nil, fmt.Errorf("reset before iteration failed: %w", err)
+			}
+		}
+		if iterations > 1 {
+			fmt.Printf("[iteration %d/%d] ", i+1, iterations)
+		}
+		result, err := op()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	if iterations == 1 && warmup == 0 {
+		return results[0], nil
+	}
+
+	aggregated := models.AggregateResults(results, warmup)
+	b.logAggregate(aggregated)
+	return aggregated, nil
+}
+
+func (b *BaseBenchmark) newResult(operation string, recordsCount int) *models.BenchmarkResult {
+	result := models.NewBenchmarkResult(operation, b.name, recordsCount)
+	result.SetPercentiles(b.config.Benchmark.Percentiles)
+	return result
+}
+
 func (b *BaseBenchmark) logProgress(operation string, current, total int) {
 	if total > 0 && current%10000 == 0 {
 		percent := float64(current) / float64(total) * 100
@@ -108,4 +263,36 @@ func (b *BaseBenchmark) logComplete(operation string, result *models.BenchmarkRe
 		result.Throughput,
 		result.ErrorCount,
 		result.ErrorRate*100)
+
+	if len(result.Timeline) > 0 {
+		fmt.Printf("  Completed %d operations in %v (%d timeline samples)\n",
+			result.RecordsCount,
+			result.Duration.Round(time.Millisecond),
+			len(result.Timeline))
+	}
+
+	if result.Latency != nil {
+		fmt.Printf("  Latency: min %v, mean %v, max %v, stddev %v",
+			result.Latency.Min,
+			result.Latency.Mean,
+			result.Latency.Max,
+			result.Latency.StdDev)
+		for _, p := range result.Latency.Percentiles {
+			fmt.Printf(", %s %v", metrics.PercentileLabel(p.Percentile), p.Value)
+		}
+		fmt.Println()
+	}
+}
+
+func (b *BaseBenchmark) logAggregate(result *models.BenchmarkResult) {
+	it := result.Iterations
+	fmt.Printf("%s: Σ %d iterations (%d warmup), Throughput: mean %.0f ops/s, median %.0f, stddev %.0f, 95%% CI [%.0f, %.0f]\n",
+		result.Operation,
+		it.Count,
+		it.Warmup,
+		it.Throughput.Mean,
+		it.Throughput.Median,
+		it.Throughput.StdDev,
+		it.Throughput.CI95Low,
+		it.Throughput.CI95High)
 }
@@ -0,0 +1,446 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/config"
+	"github.com/nadmax/dbcompare/internal/database"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+const (
+	indexedQueries            = 1000
+	concurrentReadsPerWorker  = 1000
+	concurrentWritesPerWorker = 100
+)
+
+type operation struct {
+	name  string
+	run   func() (*models.BenchmarkResult, error)
+	reset func() error
+}
+
+// DriverBenchmark runs the shared operation catalog against any registered
+// database driver.
+type DriverBenchmark struct {
+	BaseBenchmark
+	driver database.Driver
+
+	// keys counts the records in the table: Bulk Insert sets it and every
+	// other successful insert adds one. The choosers pick the keys of reads,
+	// updates and transfers among them, and latest follows its growth.
+	keys            atomic.Int64
+	readKeys        generator.KeyChooser
+	updateKeys      generator.KeyChooser
+	transactionKeys generator.KeyChooser
+}
+
+func NewDriverBenchmark(driver database.Driver, cfg *config.Config) *DriverBenchmark {
+	d := &DriverBenchmark{
+		BaseBenchmark: BaseBenchmark{
+			name:   driver.Name(),
+			config: cfg,
+			seeds:  generator.NewStreams(cfg.Benchmark.Seed),
+		},
+		driver: driver,
+	}
+	d.streams = d.seeds
+
+	d.keys.Store(int64(cfg.Benchmark.RecordCount))
+	d.readKeys = generator.NewKeyChooser(cfg.Benchmark.Distributions.RandomRead, &d.keys)
+	d.updateKeys = generator.NewKeyChooser(cfg.Benchmark.Distributions.Update, &d.keys)
+	d.transactionKeys = generator.NewKeyChooser(cfg.Benchmark.Distributions.Transaction, &d.keys)
+	return d
+}
+
+func (d *DriverBenchmark) Setup() error {
+	fmt.Printf("Setting up %s schema...\n", d.name)
+	return d.driver.CreateSchema()
+}
+
+func (d *DriverBenchmark) Teardown() error {
+	return d.driver.Close()
+}
+
+func (d *DriverBenchmark) operations() []operation {
+	ops := append(d.bulkInserts(), []operation{
+		{"Sequential Read", d.sequentialRead, nil},
+		{"Random Read", d.randomRead, nil},
+		{"Indexed Query", d.indexedQuery, nil},
+		{"Update Operations", d.updateOperations, nil},
+		{"Complex Query", d.complexQuery, nil},
+	}...)
+
+	if levels := d.config.Benchmark.ConcurrencyLevels; len(levels) > 0 {
+		for _, level := range levels {
+			ops = append(ops,
+				operation{d.concurrencyName("Concurrent Reads", level), func() (*models.BenchmarkResult, error) { return d.concurrentReads(level) }, nil},
+				operation{d.concurrencyName("Concurrent Writes", level), func() (*models.BenchmarkResult, error) { return d.concurrentWrites(level) }, nil},
+			)
+		}
+	} else {
+		goroutines := d.config.Benchmark.ConcurrentGoroutines
+		ops = append(ops,
+			operation{"Concurrent Reads", func() (*models.BenchmarkResult, error) { return d.concurrentReads(goroutines) }, nil},
+			operation{"Concurrent Writes", func() (*models.BenchmarkResult, error) { return d.concurrentWrites(goroutines) }, nil},
+		)
+	}
+
+	ops = append(ops, operation{"Transaction Performance", d.transactionPerformance, nil})
+
+	if d.config.Benchmark.Mixed.Enabled {
+		for _, ratio := range d.config.Benchmark.Mixed.Ratios {
+			ops = append(ops, operation{mixedName(ratio), func() (*models.BenchmarkResult, error) { return d.mixedWorkload(ratio) }, nil})
+		}
+	}
+
+	if d.config.Benchmark.OpenLoop.Enabled {
+		for _, rate := range d.config.Benchmark.OpenLoop.Rates {
+			ops = append(ops,
+				operation{openLoopName("Reads", rate), func() (*models.BenchmarkResult, error) { return d.openLoopReads(rate) }, nil},
+				operation{openLoopName("Writes", rate), func() (*models.BenchmarkResult, error) { return d.openLoopWrites(rate) }, nil},
+			)
+		}
+	}
+
+	return ops
+}
+
+// concurrencyName keeps the plain operation name when no sweep is configured,
+// so results stay comparable with earlier runs.
+func (d *DriverBenchmark) concurrencyName(operation string, level int) string {
+	if len(d.config.Benchmark.ConcurrencyLevels) == 0 {
+		return operation
+	}
+	return fmt.Sprintf("%s (%d workers)", operation, level)
+}
+
+func openLoopName(kind string, rate float64) string {
+	return fmt.Sprintf("Open-Loop %s @ %g/s", kind, rate)
+}
+
+func (d *DriverBenchmark) Run() ([]models.BenchmarkResult, error) {
+	results := make([]models.BenchmarkResult, 0)
+
+	for _, op := range d.operations() {
+		result, err := d.measure(op.run, op.reset)
+		if d.succeeded(op.name, err) {
+			results = append(results, *result)
+		}
+	}
+
+	return results, nil
+}
+
+// succeeded reports whether a measured operation produced a result, logging
+// why it did not otherwise.
+func (d *DriverBenchmark) succeeded(name string, err error) bool {
+	switch {
+	case errors.Is(err, database.ErrUnsupported):
+		fmt.Printf("– %s not supported by %s, skipped\n", name, d.name)
+	case err != nil:
+		fmt.Printf("⚠ %s failed: %v\n", name, err)
+	default:
+		return true
+	}
+	return false
+}
+
+// bulkInserts runs Bulk Insert once per batch size of the sweep. The plain
+// Bulk Insert always uses the engine's default row-at-a-time Insert, so it
+// stays comparable across engines; engines with several load strategies add
+// one suffixed variant per extra strategy. Names only carry the strategy and
+// batch size when those are configured.
+func (d *DriverBenchmark) bulkInserts() []operation {
+	sizes := d.config.Benchmark.BatchSizes
+	if len(sizes) == 0 {
+		sizes = []int{d.config.Benchmark.BatchSize}
+	}
+	strategies := []string{""}
+	if loader, ok := d.driver.(database.BulkLoader); ok {
+		for _, strategy := range loader.LoadStrategies() {
+			if strategy != database.LoadRow && !slices.Contains(strategies, strategy) {
+				strategies = append(strategies, strategy)
+			}
+		}
+	}
+
+	ops := make([]operation, 0, len(strategies)*len(sizes))
+	for _, strategy := range strategies {
+		for _, size := range sizes {
+			variant := make([]string, 0, 2)
+			if strategy != "" {
+				variant = append(variant, strategy)
+			}
+			if len(d.config.Benchmark.BatchSizes) > 0 {
+				variant = append(variant, fmt.Sprintf("batch %d", size))
+			}
+			name := "Bulk Insert"
+			if len(variant) > 0 {
+				name = fmt.Sprintf("Bulk Insert (%s)", strings.Join(variant, ", "))
+			}
+			ops = append(ops, operation{name, func() (*models.BenchmarkResult, error) { return d.bulkInsert(name, strategy, size) }, d.driver.TruncateTable})
+		}
+	}
+	return ops
+}
+
+// load runs Bulk Insert at the configured batch size, for suites that load the
+// records before their own operations.
+func (d *DriverBenchmark) load() (*models.BenchmarkResult, error) {
+	return d.bulkInsert("Bulk Insert", "", d.config.Benchmark.BatchSize)
+}
+
+// bulkInsert loads the records in batches of batchSize, with the engine's
+// load strategy when one is given. Every variant draws from the Bulk Insert
+// stream, so a sweep leaves the same records behind for the operations that
+// follow.
+func (d *DriverBenchmark) bulkInsert(name, strategy string, batchSize int) (*models.BenchmarkResult, error) {
+	total := d.config.Benchmark.RecordCount
+	result := d.newResult(name, total)
+	result.SetMetadata("batch_size", batchSize)
+	gen := d.generator("Bulk Insert")
+
+	insert := d.driver.Insert
+	if loader, ok := d.driver.(database.BulkLoader); ok && len(loader.LoadStrategies()) > 0 {
+		if strategy == "" {
+			result.SetMetadata("strategy", database.LoadRow)
+		} else {
+			insert = func(records []models.TestRecord) (int, error) { return loader.InsertWith(strategy, records) }
+			result.SetMetadata("strategy", strategy)
+			result.SetMetadata(models.EngineSpecific, true)
+		}
+	}
+
+	// Later operations read the loaded dataset, so the load stays count-driven
+	// even when every other operation is time-bounded.
+	if d.durationMode() {
+		fmt.Printf("  %s loads all %d records; it is not time-bounded in duration mode\n", name, total)
+	}
+
+	errorCount := 0
+	for i := 0; i < total; i += batchSize {
+		end := min(i+batchSize, total)
+		records := gen.GenerateRecords(end-i, i+1)
+
+		start := time.Now()
+		failed, err := insert(records)
+		result.Observe(start)
+		if err != nil {
+			errorCount += len(records)
+		} else {
+			errorCount += failed
+		}
+
+		d.logProgress(name, end, total)
+	}
+	d.keys.Store(int64(total))
+
+	result.Complete(errorCount)
+	d.logComplete(name, result)
+	return result, nil
+}
+
+// insertOne inserts a single record and, once it is stored, counts its key so
+// that the latest distribution can pick it.
+func (d *DriverBenchmark) insertOne(record models.TestRecord) error {
+	failed, err := d.driver.Insert([]models.TestRecord{record})
+	if err == nil && failed > 0 {
+		err = fmt.Errorf("insert failed")
+	}
+	if err == nil {
+		d.keys.Add(1)
+	}
+	return err
+}
+
+func (d *DriverBenchmark) sequentialRead() (*models.BenchmarkResult, error) {
+	limit := d.config.Benchmark.RecordCount
+	result := d.newResult("Sequential Read", limit)
+
+	errorCount, err := d.run(result, 1, d.limit(1), func(_, _ int) (int, error) {
+		return d.driver.Scan(limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete("Sequential Read", result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) randomRead() (*models.BenchmarkResult, error) {
+	count := d.config.Benchmark.RandomReads
+	result := d.newResult("Random Read", count)
+	result.SetMetadata("distribution", d.config.Benchmark.Distributions.RandomRead.Type)
+	gen := d.generator("Random Read")
+
+	errorCount, err := d.loop(result, count, func(_ int) error {
+		return d.driver.ReadByID(d.readKeys.Next(gen))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete("Random Read", result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) indexedQuery() (*models.BenchmarkResult, error) {
+	result := d.newResult("Indexed Query", indexedQueries)
+
+	errorCount, err := d.loop(result, indexedQueries, func(i int) error {
+		age := 20 + (i % 50)
+		return d.driver.QueryByAge(age, 10)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete("Indexed Query", result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) updateOperations() (*models.BenchmarkResult, error) {
+	count := d.config.Benchmark.Updates
+	result := d.newResult("Update Operations", count)
+	result.SetMetadata("distribution", d.config.Benchmark.Distributions.Update.Type)
+	gen := d.generator("Update Operations")
+
+	errorCount, err := d.loop(result, count, func(_ int) error {
+		id := d.updateKeys.Next(gen)
+		newBalance := gen.GenerateUpdateValue("balance").(float64)
+		return d.driver.UpdateBalance(id, newBalance)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete("Update Operations", result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) complexQuery() (*models.BenchmarkResult, error) {
+	result := d.newResult("Complex Query", 1)
+
+	errorCount, err := d.loop(result, 1, func(_ int) error {
+		_, err := d.driver.Aggregate()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete("Complex Query", result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) concurrentReads(goroutines int) (*models.BenchmarkResult, error) {
+	name := d.concurrencyName("Concurrent Reads", goroutines)
+	result := d.newResult(name, goroutines*concurrentReadsPerWorker)
+	result.SetMetadata("operation", "Concurrent Reads")
+	result.SetMetadata("concurrency", goroutines)
+	result.SetMetadata("distribution", d.config.Benchmark.Distributions.RandomRead.Type)
+	gens := d.generators(name, goroutines)
+
+	errorCount, err := d.parallel(result, goroutines, concurrentReadsPerWorker, func(worker, _ int) error {
+		return d.driver.ReadByID(d.readKeys.Next(gens[worker]))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete(name, result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) concurrentWrites(goroutines int) (*models.BenchmarkResult, error) {
+	name := d.concurrencyName("Concurrent Writes", goroutines)
+	result := d.newResult(name, goroutines*concurrentWritesPerWorker)
+	result.SetMetadata("operation", "Concurrent Writes")
+	result.SetMetadata("concurrency", goroutines)
+	gens := d.generators(name, goroutines)
+
+	errorCount, err := d.parallel(result, goroutines, concurrentWritesPerWorker, func(worker, i int) error {
+		record := gens[worker].GenerateRecord(200000 + worker*concurrentWritesPerWorker + i)
+		return d.insertOne(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete(name, result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) transactionPerformance() (*models.BenchmarkResult, error) {
+	count := d.config.Benchmark.Transactions
+	result := d.newResult("Transaction Performance", count)
+	result.SetMetadata("distribution", d.config.Benchmark.Distributions.Transaction.Type)
+	gen := d.generator("Transaction Performance")
+
+	errorCount, err := d.loop(result, count, func(_ int) error {
+		id1 := d.transactionKeys.Next(gen)
+		id2 := d.transactionKeys.Next(gen)
+		return d.driver.Transfer(id1, id2, 10)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete("Transaction Performance", result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) openLoopReads(rate float64) (*models.BenchmarkResult, error) {
+	name := openLoopName("Reads", rate)
+	openLoop := d.config.Benchmark.OpenLoop
+	result := d.newResult(name, 0)
+	gens := d.generators(name, openLoop.Workers)
+
+	errorCount, err := d.openLoop(result, rate, openLoop.Duration, openLoop.Workers, func(worker int) error {
+		return d.driver.ReadByID(d.readKeys.Next(gens[worker]))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.SetMetadata("operation", "Concurrent Reads")
+	result.Complete(errorCount)
+	d.logComplete(name, result)
+	return result, nil
+}
+
+func (d *DriverBenchmark) openLoopWrites(rate float64) (*models.BenchmarkResult, error) {
+	name := openLoopName("Writes", rate)
+	openLoop := d.config.Benchmark.OpenLoop
+	result := d.newResult(name, 0)
+	gens := d.generators(name, openLoop.Workers)
+
+	errorCount, err := d.openLoop(result, rate, openLoop.Duration, openLoop.Workers, func(worker int) error {
+		record := gens[worker].GenerateRecord(0)
+		return d.insertOne(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.SetMetadata("operation", "Concurrent Writes")
+	result.Complete(errorCount)
+	d.logComplete(name, result)
+	return result, nil
+}
@@ -0,0 +1,129 @@
+package benchmarks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/database"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+const (
+	// documentInsertBatch bounds the documents sent per insert, keeping
+	// batches of 100KB documents within the engines' message limits.
+	documentInsertBatch = 100
+	documentQueryLimit  = 10
+)
+
+// DocumentsBenchmark loads nested documents of variable size and then queries
+// them by nested path and array membership, updates a nested field and
+// appends to an array.
+type DocumentsBenchmark struct {
+	*DriverBenchmark
+}
+
+func NewDocumentsBenchmark(d *DriverBenchmark) *DocumentsBenchmark {
+	return &DocumentsBenchmark{DriverBenchmark: d}
+}
+
+func (b *DocumentsBenchmark) Setup() error {
+	return nil
+}
+
+func (b *DocumentsBenchmark) Run() ([]models.BenchmarkResult, error) {
+	driver, ok := b.driver.(database.DocumentDriver)
+	if !ok {
+		b.succeeded("Documents", database.ErrUnsupported)
+		return nil, nil
+	}
+
+	results := make([]models.BenchmarkResult, 0)
+	result, err := b.measure(func() (*models.BenchmarkResult, error) { return b.insert(driver) }, driver.CreateDocumentSchema)
+	if !b.succeeded("Document Insert", err) {
+		return results, nil
+	}
+	results = append(results, *result)
+
+	ops := []struct {
+		name string
+		call func(gen *generator.Generator, id int) error
+	}{
+		{"Nested Path Query", func(gen *generator.Generator, _ int) error {
+			_, err := driver.QueryDocumentsByCity(gen.DocumentCity(), documentQueryLimit)
+			return err
+		}},
+		{"Array Contains Query", func(gen *generator.Generator, _ int) error {
+			_, err := driver.QueryDocumentsByTag(gen.DocumentTag(), documentQueryLimit)
+			return err
+		}},
+		{"Nested Field Update", func(gen *generator.Generator, id int) error {
+			return driver.UpdateDocumentTier(id, gen.DocumentTier())
+		}},
+		{"Array Append", func(gen *generator.Generator, id int) error {
+			return driver.AppendDocumentEvent(id, gen.GenerateDocumentEvent(200))
+		}},
+	}
+	for _, op := range ops {
+		result, err := b.measure(func() (*models.BenchmarkResult, error) { return b.operation(op.name, op.call) }, nil)
+		if b.succeeded(op.name, err) {
+			results = append(results, *result)
+		}
+	}
+
+	return results, nil
+}
+
+func (b *DocumentsBenchmark) insert(driver database.DocumentDriver) (*models.BenchmarkResult, error) {
+	cfg := b.config.Benchmark.Documents
+	result := b.newResult("Document Insert", cfg.Count)
+	result.SetMetadata("min_size", cfg.MinSize)
+	result.SetMetadata("max_size", cfg.MaxSize)
+	gen := b.generator("Document Insert")
+
+	errorCount := 0
+	totalSize := 0
+	for i := 0; i < cfg.Count; i += documentInsertBatch {
+		end := min(i+documentInsertBatch, cfg.Count)
+		docs := make([]models.Document, 0, end-i)
+		for id := i + 1; id <= end; id++ {
+			size := gen.GenerateDocumentSize(cfg.MinSize, cfg.MaxSize)
+			totalSize += size
+			docs = append(docs, gen.GenerateDocument(id, size))
+		}
+
+		start := time.Now()
+		failed, err := driver.InsertDocuments(docs)
+		result.Observe(start)
+		if err != nil {
+			errorCount += len(docs)
+		} else {
+			errorCount += failed
+		}
+
+		b.logProgress("Document Insert", end, cfg.Count)
+	}
+
+	result.SetMetadata("mean_size", totalSize/max(cfg.Count, 1))
+	result.Complete(errorCount)
+	b.logComplete("Document Insert", result)
+	fmt.Printf("  Mean document size: %d bytes\n", totalSize/max(cfg.Count, 1))
+	return result, nil
+}
+
+func (b *DocumentsBenchmark) operation(name string, call func(gen *generator.Generator, id int) error) (*models.BenchmarkResult, error) {
+	count := b.config.Benchmark.Documents.Operations
+	result := b.newResult(name, count)
+	gen := b.generator(name)
+
+	errorCount, err := b.loop(result, count, func(_ int) error {
+		return call(gen, gen.GenerateRandomID(b.config.Benchmark.Documents.Count))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	b.logComplete(name, result)
+	return result, nil
+}
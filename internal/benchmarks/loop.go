@@ -0,0 +1,187 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/database"
+	"github.com/nadmax/dbcompare/internal/metrics"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+// runLimit bounds an operation either by calls per worker or, when duration
+// is set, by wall-clock time.
+type runLimit struct {
+	perWorker int
+	duration  time.Duration
+}
+
+func (b *BaseBenchmark) durationMode() bool {
+	return b.config.Benchmark.Mode == "duration"
+}
+
+func (b *BaseBenchmark) limit(perWorker int) runLimit {
+	if b.durationMode() {
+		return runLimit{duration: b.config.Benchmark.Duration}
+	}
+	return runLimit{perWorker: perWorker}
+}
+
+// loop calls fn count times, or in duration mode until the configured
+// duration elapses, and returns the number of failed calls.
+func (b *BaseBenchmark) loop(result *models.BenchmarkResult, count int, fn func(i int) error) (int, error) {
+	return b.run(result, 1, b.limit(count), func(_, i int) (int, error) {
+		return 1, fn(i)
+	})
+}
+
+// parallel is loop spread over workers goroutines, each doing perWorker calls
+// in count mode.
+func (b *BaseBenchmark) parallel(result *models.BenchmarkResult, workers, perWorker int, fn func(worker, i int) error) (int, error) {
+	return b.run(result, workers, b.limit(perWorker), func(worker, i int) (int, error) {
+		return 1, fn(worker, i)
+	})
+}
+
+// run drives the calls of one operation. fn reports how many records each call
+// processed; for time-bounded runs their sum becomes the result's RecordsCount
+// and a per-second throughput timeline is attached to the result. Every call is
+// timed into the result's latency histogram. A call returning
+// database.ErrUnsupported aborts the operation with that error.
+func (b *BaseBenchmark) run(result *models.BenchmarkResult, workers int, limit runLimit, fn func(worker, i int) (int, error)) (int, error) {
+	var (
+		deadline    time.Time
+		timeline    *metrics.Timeline
+		processed   atomic.Int64
+		calls       atomic.Int64
+		failed      atomic.Int64
+		unsupported atomic.Bool
+	)
+
+	if limit.duration > 0 {
+		deadline = time.Now().Add(limit.duration)
+		timeline = metrics.NewTimeline(time.Second)
+		timeline.Start()
+	}
+	perWorker := limit.perWorker
+	total := workers * perWorker
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			for i := 0; ; i++ {
+				if deadline.IsZero() && i >= perWorker {
+					return
+				}
+				if !deadline.IsZero() && time.Now().After(deadline) {
+					return
+				}
+				if unsupported.Load() {
+					return
+				}
+
+				start := time.Now()
+				n, err := fn(w, i)
+				if errors.Is(err, database.ErrUnsupported) {
+					unsupported.Store(true)
+					return
+				}
+				result.Observe(start)
+
+				processed.Add(int64(n))
+				if timeline != nil {
+					timeline.Add(int64(n))
+				}
+				if err != nil {
+					failed.Add(1)
+				}
+				if done := calls.Add(1); workers == 1 && deadline.IsZero() {
+					b.logProgress(result.Operation, int(done), total)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if timeline != nil {
+		result.Timeline = timeline.Stop()
+		result.RecordsCount = int(processed.Load())
+	}
+	if unsupported.Load() {
+		return 0, database.ErrUnsupported
+	}
+
+	return int(failed.Load()), nil
+}
+
+// openLoop issues calls at a fixed target rate regardless of how quickly
+// earlier calls complete. A scheduler computes the intended start time of
+// every call and hands it to a pool of workers; latency is measured from that
+// intended time, so time spent queueing behind a saturated engine is counted
+// instead of being silently omitted.
+func (b *BaseBenchmark) openLoop(result *models.BenchmarkResult, rate float64, duration time.Duration, workers int, fn func(worker int) error) (int, error) {
+	var (
+		completed   atomic.Int64
+		failed      atomic.Int64
+		unsupported atomic.Bool
+	)
+
+	interval := time.Duration(float64(time.Second) / rate)
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid open-loop rate %g/s", rate)
+	}
+	schedule := make(chan time.Time, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			for intended := range schedule {
+				if unsupported.Load() {
+					continue
+				}
+
+				err := fn(w)
+				if errors.Is(err, database.ErrUnsupported) {
+					unsupported.Store(true)
+					continue
+				}
+				result.RecordLatency(time.Since(intended))
+
+				completed.Add(1)
+				if err != nil {
+					failed.Add(1)
+				}
+			}
+		})
+	}
+
+	start := time.Now()
+	issued := 0
+	for i := 0; ; i++ {
+		intended := start.Add(time.Duration(i) * interval)
+		if intended.Sub(start) >= duration || unsupported.Load() {
+			break
+		}
+		if wait := time.Until(intended); wait > 0 {
+			time.Sleep(wait)
+		}
+		schedule <- intended
+		issued++
+	}
+	close(schedule)
+	wg.Wait()
+
+	if unsupported.Load() {
+		return 0, database.ErrUnsupported
+	}
+
+	result.RecordsCount = int(completed.Load())
+	result.SetMetadata("load_model", "open-loop")
+	result.SetMetadata("offered_rate", rate)
+	result.SetMetadata("issued", issued)
+	result.SetMetadata("workers", workers)
+	return int(failed.Load()), nil
+}
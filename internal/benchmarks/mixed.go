@@ -0,0 +1,86 @@
+package benchmarks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/config"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+const (
+	mixedRead   = "Read"
+	mixedUpdate = "Update"
+	mixedInsert = "Insert"
+)
+
+func mixedName(ratio config.MixRatio) string {
+	return fmt.Sprintf("Mixed %g/%g/%g", ratio.Read, ratio.Update, ratio.Insert)
+}
+
+// mixedWorkload runs reads, updates and inserts concurrently from the same
+// workers, each call picking its operation type at random according to ratio.
+// The result carries one breakdown entry per operation type.
+func (d *DriverBenchmark) mixedWorkload(ratio config.MixRatio) (*models.BenchmarkResult, error) {
+	mixed := d.config.Benchmark.Mixed
+	name := mixedName(ratio)
+	result := d.newResult(name, mixed.Workers*mixed.OperationsPerWorker)
+	result.SetMetadata("mix", fmt.Sprintf("%g/%g/%g", ratio.Read, ratio.Update, ratio.Insert))
+	result.SetMetadata("workers", mixed.Workers)
+	result.TrackOperations(mixedRead, mixedUpdate, mixedInsert)
+	gens := d.generators(name, mixed.Workers)
+
+	errorCount, err := d.parallel(result, mixed.Workers, mixed.OperationsPerWorker, func(worker, _ int) error {
+		gen := gens[worker]
+		op := pickMixed(gen, ratio)
+
+		start := time.Now()
+		var err error
+		switch op {
+		case mixedRead:
+			err = d.driver.ReadByID(d.readKeys.Next(gen))
+		case mixedUpdate:
+			id := d.updateKeys.Next(gen)
+			err = d.driver.UpdateBalance(id, gen.GenerateUpdateValue("balance").(float64))
+		case mixedInsert:
+			err = d.insertOne(gen.GenerateRecord(0))
+		}
+		result.RecordOperation(op, time.Since(start), err)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	d.logComplete(name, result)
+	d.logBreakdown(result)
+	return result, nil
+}
+
+func pickMixed(gen *generator.Generator, ratio config.MixRatio) string {
+	r := gen.GenerateFloat(0, ratio.Total())
+	switch {
+	case r < ratio.Read:
+		return mixedRead
+	case r < ratio.Read+ratio.Update:
+		return mixedUpdate
+	default:
+		return mixedInsert
+	}
+}
+
+func (d *DriverBenchmark) logBreakdown(result *models.BenchmarkResult) {
+	for _, b := range result.Breakdown {
+		if b.Count == 0 {
+			continue
+		}
+		mean := time.Duration(0)
+		if b.Latency != nil {
+			mean = b.Latency.Mean
+		}
+		fmt.Printf("  %-17s %5.1f%%  %8d ops  %8.0f ops/s  mean %v  errors %d\n",
+			b.Operation, b.Share*100, b.Count, b.Throughput, mean, b.ErrorCount)
+	}
+}
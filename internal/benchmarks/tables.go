@@ -0,0 +1,98 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/config"
+	"github.com/nadmax/dbcompare/internal/database"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+// TablesBenchmark loads the custom tables described under benchmark.tables,
+// measuring one load per table in foreign key order.
+type TablesBenchmark struct {
+	*DriverBenchmark
+}
+
+func NewTablesBenchmark(d *DriverBenchmark) *TablesBenchmark {
+	return &TablesBenchmark{DriverBenchmark: d}
+}
+
+func (t *TablesBenchmark) Setup() error {
+	return nil
+}
+
+func tableLoadName(table config.TableConfig) string {
+	return "Load " + table.Name
+}
+
+func (t *TablesBenchmark) Run() ([]models.BenchmarkResult, error) {
+	tables := t.config.Benchmark.Tables
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables configured under benchmark.tables")
+	}
+
+	driver, ok := t.driver.(database.TableDriver)
+	if !ok {
+		t.succeeded("Custom tables", database.ErrUnsupported)
+		return nil, nil
+	}
+
+	rows := make(map[string]int, len(tables))
+	for _, table := range tables {
+		rows[table.Name] = table.Rows
+	}
+
+	results := make([]models.BenchmarkResult, 0, len(tables))
+	for i, table := range tables {
+		// Recreating the table and the ones loaded after it leaves the tables
+		// it references loaded, so foreign keys always resolve.
+		reset := func() error { return driver.CreateTables(tables[i:]) }
+		gen := generator.NewTable(table, rows)
+
+		result, err := t.measure(func() (*models.BenchmarkResult, error) { return t.load(driver, table, gen) }, reset)
+		// Later tables may reference this one, so a failed load ends the run.
+		if !t.succeeded(tableLoadName(table), err) {
+			return results, nil
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+func (t *TablesBenchmark) load(driver database.TableDriver, table config.TableConfig, rows *generator.Table) (*models.BenchmarkResult, error) {
+	name := tableLoadName(table)
+	batchSize := t.config.Benchmark.BatchSize
+	result := t.newResult(name, table.Rows)
+	result.SetMetadata("table", table.Name)
+	result.SetMetadata("columns", len(table.Columns))
+	gen := t.generator(name)
+
+	errorCount := 0
+	for i := 0; i < table.Rows; i += batchSize {
+		end := min(i+batchSize, table.Rows)
+		batch := rows.Rows(gen, end-i, i+1)
+
+		start := time.Now()
+		failed, err := driver.InsertRows(table, batch)
+		result.Observe(start)
+		if errors.Is(err, database.ErrUnsupported) {
+			return nil, err
+		}
+		if err != nil {
+			errorCount += len(batch)
+		} else {
+			errorCount += failed
+		}
+
+		t.logProgress(name, end, table.Rows)
+	}
+
+	result.Complete(errorCount)
+	t.logComplete(name, result)
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/nadmax/dbcompare/internal/database"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+// tpcbMaxDelta bounds the amount moved by one transaction, as in pgbench.
+const tpcbMaxDelta = 5000
+
+// TPCBBenchmark runs a TPC-B-like banking workload and checks afterwards that
+// no transaction lost or duplicated money.
+type TPCBBenchmark struct {
+	*DriverBenchmark
+}
+
+func NewTPCBBenchmark(d *DriverBenchmark) *TPCBBenchmark {
+	return &TPCBBenchmark{DriverBenchmark: d}
+}
+
+func (t *TPCBBenchmark) Setup() error {
+	return nil
+}
+
+func (t *TPCBBenchmark) operationName() string {
+	cfg := t.config.Benchmark.TPCB
+	return fmt.Sprintf("TPC-B (scale %d, %d clients)", cfg.Scale, cfg.Clients)
+}
+
+func (t *TPCBBenchmark) Run() ([]models.BenchmarkResult, error) {
+	name := t.operationName()
+	driver, ok := t.driver.(database.TPCBDriver)
+	if !ok {
+		t.succeeded(name, database.ErrUnsupported)
+		return nil, nil
+	}
+
+	scale := t.config.Benchmark.TPCB.Scale
+	fmt.Printf("Setting up %s TPC-B tables (scale %d)...\n", t.name, scale)
+	if err := driver.CreateTPCBSchema(scale); err != nil {
+		if errors.Is(err, database.ErrUnsupported) {
+			t.succeeded(name, err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("tpcb setup failed: %w", err)
+	}
+
+	result, err := t.measure(func() (*models.BenchmarkResult, error) { return t.transactions(driver) }, nil)
+	if !t.succeeded(name, err) {
+		return nil, nil
+	}
+
+	totals, err := driver.TPCBTotals()
+	if err != nil {
+		return nil, fmt.Errorf("tpcb verification failed: %w", err)
+	}
+	result.SetMetadata("totals", totals)
+	result.SetMetadata("balance_conserved", totals.Conserved())
+	if totals.Conserved() {
+		fmt.Printf("  ✓ Balance conserved: Σ accounts = Σ tellers = Σ branches = Σ history = %d (%d history rows)\n",
+			totals.Accounts, totals.HistoryRows)
+	} else {
+		fmt.Printf("  ⚠ Balance NOT conserved: Σ accounts %d, Σ tellers %d, Σ branches %d, Σ history %d\n",
+			totals.Accounts, totals.Tellers, totals.Branches, totals.History)
+	}
+
+	return []models.BenchmarkResult{*result}, nil
+}
+
+func (t *TPCBBenchmark) transactions(driver database.TPCBDriver) (*models.BenchmarkResult, error) {
+	cfg := t.config.Benchmark.TPCB
+	name := t.operationName()
+	perWorker := max(cfg.Transactions/cfg.Clients, 1)
+	result := t.newResult(name, perWorker*cfg.Clients)
+	result.SetMetadata("scale", cfg.Scale)
+	result.SetMetadata("clients", cfg.Clients)
+	result.SetMetadata("distribution", t.config.Benchmark.Distributions.Transaction.Type)
+
+	var accounts atomic.Int64
+	accounts.Store(int64(cfg.Scale * database.TPCBAccountsPerBranch))
+	accountKeys := generator.NewKeyChooser(t.config.Benchmark.Distributions.Transaction, &accounts)
+
+	gens := t.generators(name, cfg.Clients)
+
+	errorCount, err := t.parallel(result, cfg.Clients, perWorker, func(worker, _ int) error {
+		gen := gens[worker]
+		aid := accountKeys.Next(gen)
+		tid := gen.GenerateRandomID(cfg.Scale * database.TPCBTellersPerBranch)
+		bid := gen.GenerateRandomID(cfg.Scale)
+		delta := gen.GenerateInt(-tpcbMaxDelta, tpcbMaxDelta)
+		return driver.TPCBTransaction(aid, tid, bid, delta)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	t.logComplete(name, result)
+	return result, nil
+}
@@ -0,0 +1,204 @@
+package benchmarks
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/database"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+const (
+	tpccNewOrder    = "New-Order"
+	tpccPayment     = "Payment"
+	tpccOrderStatus = "Order-Status"
+	tpccDelivery    = "Delivery"
+	tpccStockLevel  = "Stock-Level"
+)
+
+// tpccMix is the minimum transaction mix of the TPC-C specification, in
+// percent, with New-Order taking the remainder.
+var tpccMix = []struct {
+	name    string
+	percent float64
+}{
+	{tpccNewOrder, 45},
+	{tpccPayment, 43},
+	{tpccOrderStatus, 4},
+	{tpccDelivery, 4},
+	{tpccStockLevel, 4},
+}
+
+// TPCCBenchmark runs a scaled-down TPC-C order-entry workload. Terminals run
+// the standard transaction mix without keying or think times, and the result
+// reports tpmC, the number of New-Order transactions per minute.
+type TPCCBenchmark struct {
+	*DriverBenchmark
+}
+
+func NewTPCCBenchmark(d *DriverBenchmark) *TPCCBenchmark {
+	return &TPCCBenchmark{DriverBenchmark: d}
+}
+
+func (t *TPCCBenchmark) Setup() error {
+	return nil
+}
+
+func (t *TPCCBenchmark) operationName() string {
+	cfg := t.config.Benchmark.TPCC
+	return fmt.Sprintf("TPC-C (%d warehouses, %d terminals)", cfg.Warehouses, cfg.Terminals)
+}
+
+func (t *TPCCBenchmark) Run() ([]models.BenchmarkResult, error) {
+	name := t.operationName()
+	driver, ok := t.driver.(database.TPCCDriver)
+	if !ok {
+		t.unsupported(name)
+		return nil, nil
+	}
+
+	warehouses := t.config.Benchmark.TPCC.Warehouses
+	fmt.Printf("Loading %s TPC-C tables (%d warehouses)...\n", t.name, warehouses)
+	start := time.Now()
+	if err := driver.CreateTPCCSchema(warehouses, generator.DeriveSeed(t.config.Benchmark.Seed, "TPC-C load")); err != nil {
+		if errors.Is(err, database.ErrUnsupported) {
+			t.unsupported(name)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("tpcc setup failed: %w", err)
+	}
+	fmt.Printf("  Loaded in %v\n", time.Since(start).Round(time.Millisecond))
+
+	result, err := t.measure(func() (*models.BenchmarkResult, error) { return t.transactions(driver) }, nil)
+	if !t.succeeded(name, err) {
+		return nil, nil
+	}
+
+	// Failed and rolled-back New-Orders do not count towards tpmC, so an
+	// engine cannot improve it by aborting transactions.
+	for _, b := range result.Breakdown {
+		if b.Operation == tpccNewOrder && result.Duration > 0 {
+			tpmC := float64(b.Count-b.ErrorCount) / result.Duration.Seconds() * 60
+			result.SetMetadata("tpmC", tpmC)
+			fmt.Printf("  tpmC: %.0f (%.0f transactions/min overall, %.2f%% of New-Orders failed)\n",
+				tpmC, result.Throughput*60, b.ErrorRate*100)
+		}
+	}
+
+	return []models.BenchmarkResult{*result}, nil
+}
+
+// unsupported reports an engine without TPC-C, so a missing TPC-C result in
+// the comparison is explained in the run output.
+func (t *TPCCBenchmark) unsupported(name string) {
+	t.succeeded(name, database.ErrUnsupported)
+	fmt.Printf("  TPC-C is implemented for PostgreSQL, MySQL/MariaDB and SQLite only; %s has no TPC-C result to compare\n", t.name)
+}
+
+func (t *TPCCBenchmark) transactions(driver database.TPCCDriver) (*models.BenchmarkResult, error) {
+	cfg := t.config.Benchmark.TPCC
+	name := t.operationName()
+	perWorker := max(cfg.Transactions/cfg.Terminals, 1)
+	result := t.newResult(name, perWorker*cfg.Terminals)
+	result.SetMetadata("warehouses", cfg.Warehouses)
+	result.SetMetadata("terminals", cfg.Terminals)
+
+	names := make([]string, len(tpccMix))
+	for i, tx := range tpccMix {
+		names[i] = tx.name
+	}
+	result.TrackOperations(names...)
+
+	gens := t.generators(name, cfg.Terminals)
+
+	errorCount, err := t.parallel(result, cfg.Terminals, perWorker, func(worker, _ int) error {
+		gen := gens[worker]
+		warehouse := worker%cfg.Warehouses + 1
+		tx := pickTransaction(gen)
+
+		start := time.Now()
+		err := t.execute(gen, driver, tx, warehouse)
+		result.RecordOperation(tx, time.Since(start), err)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	t.logComplete(name, result)
+	t.logBreakdown(result)
+	return result, nil
+}
+
+func pickTransaction(gen *generator.Generator) string {
+	r := gen.GenerateFloat(0, 100)
+	for _, tx := range tpccMix {
+		r -= tx.percent
+		if r < 0 {
+			return tx.name
+		}
+	}
+	return tpccNewOrder
+}
+
+func (t *TPCCBenchmark) execute(gen *generator.Generator, driver database.TPCCDriver, tx string, warehouse int) error {
+	district := gen.GenerateInt(1, database.TPCCDistrictsPerWarehouse)
+	customer := gen.GenerateInt(1, database.TPCCCustomersPerDistrict)
+
+	switch tx {
+	case tpccNewOrder:
+		return driver.TPCCNewOrder(t.newOrder(gen, warehouse, district, customer))
+	case tpccPayment:
+		return driver.TPCCPayment(database.TPCCPayment{
+			WarehouseID: warehouse,
+			DistrictID:  district,
+			CustomerID:  customer,
+			Amount:      float64(gen.GenerateInt(100, 500000)) / 100,
+		})
+	case tpccOrderStatus:
+		return driver.TPCCOrderStatus(warehouse, district, customer)
+	case tpccDelivery:
+		return driver.TPCCDelivery(warehouse, gen.GenerateInt(1, 10))
+	case tpccStockLevel:
+		return driver.TPCCStockLevel(warehouse, district, gen.GenerateInt(10, 20))
+	}
+	return fmt.Errorf("unknown tpcc transaction %q", tx)
+}
+
+// newOrder builds an order of 5 to 15 distinct items, 1% of them supplied by
+// a remote warehouse when there is more than one.
+func (t *TPCCBenchmark) newOrder(gen *generator.Generator, warehouse, district, customer int) database.TPCCNewOrder {
+	warehouses := t.config.Benchmark.TPCC.Warehouses
+	count := gen.GenerateInt(5, 15)
+	order := database.TPCCNewOrder{
+		WarehouseID: warehouse,
+		DistrictID:  district,
+		CustomerID:  customer,
+		Lines:       make([]database.TPCCOrderLine, 0, count),
+	}
+
+	seen := make(map[int]bool, count)
+	for len(order.Lines) < count {
+		item := gen.GenerateInt(1, database.TPCCItems)
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+
+		supply := warehouse
+		if warehouses > 1 && gen.GenerateInt(1, 100) == 1 {
+			for supply == warehouse {
+				supply = gen.GenerateInt(1, warehouses)
+			}
+		}
+		order.Lines = append(order.Lines, database.TPCCOrderLine{
+			ItemID:            item,
+			SupplyWarehouseID: supply,
+			Quantity:          gen.GenerateInt(1, 10),
+		})
+	}
+	return order
+}
@@ -0,0 +1,190 @@
+package benchmarks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/database"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/models"
+	"github.com/nadmax/dbcompare/internal/workload"
+)
+
+// WorkloadBenchmark runs the operations of a user-defined workload file
+// instead of the built-in catalog. engine is the driver's registration name,
+// which selects the query text of every operation. tables, when set, loads the
+// custom tables the workload queries before its operations run.
+type WorkloadBenchmark struct {
+	*DriverBenchmark
+	engine   string
+	workload *workload.Workload
+	tables   *TablesBenchmark
+}
+
+func NewWorkloadBenchmark(d *DriverBenchmark, engine string, wl *workload.Workload) *WorkloadBenchmark {
+	return &WorkloadBenchmark{
+		DriverBenchmark: d,
+		engine:          engine,
+		workload:        wl,
+	}
+}
+
+func (w *WorkloadBenchmark) Setup() error {
+	if err := w.DriverBenchmark.Setup(); err != nil {
+		return err
+	}
+
+	// Setup statements on custom tables wait until the tables are loaded.
+	if w.tables != nil {
+		return nil
+	}
+	return w.runSetup()
+}
+
+func (w *WorkloadBenchmark) runSetup() error {
+	for _, query := range w.workload.Setup[w.engine] {
+		if _, err := w.driver.Execute(query, nil); err != nil {
+			return fmt.Errorf("workload setup failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (w *WorkloadBenchmark) Run() ([]models.BenchmarkResult, error) {
+	results := make([]models.BenchmarkResult, 0)
+	fmt.Printf("Workload: %s\n", w.workload.Name)
+	w.workload.Reset()
+
+	if w.workload.LoadDataset {
+		result, err := w.measure(w.load, w.driver.TruncateTable)
+		if err != nil {
+			return nil, fmt.Errorf("loading dataset failed: %w", err)
+		}
+		results = append(results, *result)
+	}
+
+	if w.tables != nil {
+		loads, err := w.tables.Run()
+		if err != nil {
+			return nil, err
+		}
+		if len(loads) < len(w.config.Benchmark.Tables) {
+			fmt.Printf("– %s: custom tables not loaded, workload skipped\n", w.workload.Name)
+			return append(results, loads...), nil
+		}
+		results = append(results, loads...)
+		if err := w.runSetup(); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range w.workload.Operations {
+		op := &w.workload.Operations[i]
+		if !op.Standalone() {
+			continue
+		}
+		if _, ok := op.Queries[w.engine]; !ok {
+			fmt.Printf("– %s has no query for %s, skipped\n", op.Name, w.engine)
+			continue
+		}
+
+		result, err := w.measure(func() (*models.BenchmarkResult, error) { return w.runOperation(op) }, nil)
+		if w.succeeded(op.Name, err) {
+			results = append(results, *result)
+		}
+	}
+
+	if w.workload.Mix != nil {
+		result, err := w.measure(w.runMix, nil)
+		if w.succeeded(w.workload.Mix.Name, err) {
+			results = append(results, *result)
+		}
+	}
+
+	return results, nil
+}
+
+func (w *WorkloadBenchmark) runOperation(op *workload.Operation) (*models.BenchmarkResult, error) {
+	query := op.Queries[w.engine]
+	limit := workloadLimit(op.Count, op.Duration, op.Concurrency)
+	result := w.newResult(op.Name, limit.perWorker*op.Concurrency)
+	result.SetMetadata("workload", w.workload.Name)
+	result.SetMetadata("concurrency", op.Concurrency)
+
+	gens := w.generators(op.Name, op.Concurrency)
+
+	errorCount, err := w.run(result, op.Concurrency, limit, func(worker, _ int) (int, error) {
+		_, err := w.driver.Execute(query, op.Args(gens[worker], w.config.Benchmark.RecordCount))
+		return 1, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	w.logComplete(op.Name, result)
+	return result, nil
+}
+
+// runMix interleaves the weighted operations that have a query for this
+// engine, choosing each call's operation at random in proportion to its
+// weight.
+func (w *WorkloadBenchmark) runMix() (*models.BenchmarkResult, error) {
+	mix := w.workload.Mix
+
+	var (
+		ops     []*workload.Operation
+		weights []float64
+		total   float64
+	)
+	for i := range w.workload.Operations {
+		op := &w.workload.Operations[i]
+		if _, ok := op.Queries[w.engine]; ok && op.Weight > 0 {
+			ops = append(ops, op)
+			total += op.Weight
+			weights = append(weights, total)
+		}
+	}
+	if len(ops) == 0 {
+		return nil, database.ErrUnsupported
+	}
+
+	limit := workloadLimit(mix.Count, mix.Duration, mix.Concurrency)
+	result := w.newResult(mix.Name, limit.perWorker*mix.Concurrency)
+	result.SetMetadata("workload", w.workload.Name)
+	result.SetMetadata("concurrency", mix.Concurrency)
+
+	gens := w.generators(mix.Name, mix.Concurrency)
+	pick := func(gen *generator.Generator) *workload.Operation {
+		r := gen.GenerateFloat(0, total)
+		for i, cumulative := range weights {
+			if r < cumulative {
+				return ops[i]
+			}
+		}
+		return ops[len(ops)-1]
+	}
+
+	errorCount, err := w.run(result, mix.Concurrency, limit, func(worker, _ int) (int, error) {
+		gen := gens[worker]
+		op := pick(gen)
+		_, err := w.driver.Execute(op.Queries[w.engine], op.Args(gen, w.config.Benchmark.RecordCount))
+		return 1, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	w.logComplete(mix.Name, result)
+	return result, nil
+}
+
+// workloadLimit spreads count calls over the workers, or bounds the step by
+// duration when one is set.
+func workloadLimit(count int, duration time.Duration, workers int) runLimit {
+	if duration > 0 {
+		return runLimit{duration: duration}
+	}
+	return runLimit{perWorker: max((count+workers-1)/workers, 1)}
+}
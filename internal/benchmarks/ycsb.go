@@ -0,0 +1,159 @@
+package benchmarks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/config"
+	"github.com/nadmax/dbcompare/internal/generator"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+const (
+	ycsbRead            = "Read"
+	ycsbUpdate          = "Update"
+	ycsbInsert          = "Insert"
+	ycsbScan            = "Scan"
+	ycsbReadModifyWrite = "Read-Modify-Write"
+)
+
+// ycsbWorkload is one of the YCSB core workloads, as defined in the
+// workloads/workload[a-f] files of the YCSB distribution.
+type ycsbWorkload struct {
+	id           string
+	description  string
+	proportions  map[string]float64
+	distribution string
+}
+
+var ycsbWorkloads = map[string]ycsbWorkload{
+	"A": {"A", "update heavy", map[string]float64{ycsbRead: 0.5, ycsbUpdate: 0.5}, "zipfian"},
+	"B": {"B", "read mostly", map[string]float64{ycsbRead: 0.95, ycsbUpdate: 0.05}, "zipfian"},
+	"C": {"C", "read only", map[string]float64{ycsbRead: 1}, "zipfian"},
+	"D": {"D", "read latest", map[string]float64{ycsbRead: 0.95, ycsbInsert: 0.05}, "latest"},
+	"E": {"E", "short ranges", map[string]float64{ycsbScan: 0.95, ycsbInsert: 0.05}, "zipfian"},
+	"F": {"F", "read-modify-write", map[string]float64{ycsbRead: 0.5, ycsbReadModifyWrite: 0.5}, "zipfian"},
+}
+
+// ycsbOperations fixes the order operation types are picked and reported in.
+var ycsbOperations = []string{ycsbRead, ycsbUpdate, ycsbInsert, ycsbScan, ycsbReadModifyWrite}
+
+func (w ycsbWorkload) name() string {
+	return fmt.Sprintf("YCSB %s (%s)", w.id, w.description)
+}
+
+// YCSBBenchmark loads the records table once and then runs the configured YCSB
+// core workloads against it, in place of the built-in catalog.
+type YCSBBenchmark struct {
+	*DriverBenchmark
+}
+
+func NewYCSBBenchmark(d *DriverBenchmark) *YCSBBenchmark {
+	return &YCSBBenchmark{DriverBenchmark: d}
+}
+
+func (y *YCSBBenchmark) Run() ([]models.BenchmarkResult, error) {
+	results := make([]models.BenchmarkResult, 0)
+
+	fmt.Println("YCSB load phase")
+	result, err := y.measure(y.load, y.driver.TruncateTable)
+	if err != nil {
+		return nil, fmt.Errorf("ycsb load failed: %w", err)
+	}
+	results = append(results, *result)
+	y.keys.Store(int64(y.config.Benchmark.RecordCount))
+
+	for _, id := range y.config.Benchmark.YCSB.Workloads {
+		workload := ycsbWorkloads[id]
+		result, err := y.measure(func() (*models.BenchmarkResult, error) { return y.runWorkload(workload) }, nil)
+		if y.succeeded(workload.name(), err) {
+			results = append(results, *result)
+		}
+	}
+
+	return results, nil
+}
+
+func (y *YCSBBenchmark) runWorkload(workload ycsbWorkload) (*models.BenchmarkResult, error) {
+	cfg := y.config.Benchmark.YCSB
+	name := workload.name()
+	distribution := workload.distribution
+	if cfg.RequestDistribution != "" {
+		distribution = cfg.RequestDistribution
+	}
+	chooser := y.chooser(distribution)
+
+	perWorker := max(cfg.OperationCount/cfg.Workers, 1)
+	result := y.newResult(name, perWorker*cfg.Workers)
+	result.SetMetadata("ycsb_workload", workload.id)
+	result.SetMetadata("distribution", distribution)
+	result.SetMetadata("workers", cfg.Workers)
+
+	var operations []string
+	for _, op := range ycsbOperations {
+		if workload.proportions[op] > 0 {
+			operations = append(operations, op)
+		}
+	}
+	result.TrackOperations(operations...)
+
+	gens := y.generators(name, cfg.Workers)
+
+	errorCount, err := y.parallel(result, cfg.Workers, perWorker, func(worker, _ int) error {
+		gen := gens[worker]
+		op := pickOperation(gen, workload, operations)
+
+		start := time.Now()
+		err := y.execute(gen, op, chooser)
+		result.RecordOperation(op, time.Since(start), err)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(errorCount)
+	y.logComplete(name, result)
+	y.logBreakdown(result)
+	return result, nil
+}
+
+func (y *YCSBBenchmark) chooser(distribution string) generator.KeyChooser {
+	return generator.NewKeyChooser(config.DistributionConfig{
+		Type:  distribution,
+		Theta: y.config.Benchmark.YCSB.ZipfianTheta,
+	}, &y.keys)
+}
+
+func pickOperation(gen *generator.Generator, workload ycsbWorkload, operations []string) string {
+	r := gen.GenerateFloat(0, 1)
+	for _, op := range operations {
+		r -= workload.proportions[op]
+		if r < 0 {
+			return op
+		}
+	}
+	return operations[len(operations)-1]
+}
+
+func (y *YCSBBenchmark) execute(gen *generator.Generator, op string, chooser generator.KeyChooser) error {
+	switch op {
+	case ycsbRead:
+		return y.driver.ReadByID(chooser.Next(gen))
+	case ycsbUpdate:
+		return y.driver.UpdateBalance(chooser.Next(gen), gen.GenerateUpdateValue("balance").(float64))
+	case ycsbScan:
+		length := gen.GenerateInt(1, y.config.Benchmark.YCSB.MaxScanLength)
+		_, err := y.driver.ScanRange(chooser.Next(gen), length)
+		return err
+	case ycsbReadModifyWrite:
+		id := chooser.Next(gen)
+		if err := y.driver.ReadByID(id); err != nil {
+			return err
+		}
+		return y.driver.UpdateBalance(id, gen.GenerateUpdateValue("balance").(float64))
+	case ycsbInsert:
+		return y.insertOne(gen.GenerateRecord(0))
+	}
+	return fmt.Errorf("unknown ycsb operation %q", op)
+}
@@ -2,8 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/nadmax/dbcompare/internal/metrics"
+	"github.com/nadmax/dbcompare/internal/stats"
 	"gopkg.in/yaml.v3"
 )
 
@@ -15,8 +20,10 @@ type Config struct {
 
 type DatabasesConfig struct {
 	Postgres  PostgresConfig  `yaml:"postgres"`
+	MySQL     MySQLConfig     `yaml:"mysql"`
 	Oracle    OracleConfig    `yaml:"oracle"`
 	SurrealDB SurrealDBConfig `yaml:"surrealdb"`
+	SQLite    SQLiteConfig    `yaml:"sqlite"`
 }
 
 type PostgresConfig struct {
@@ -28,6 +35,20 @@ type PostgresConfig struct {
 	Database       string `yaml:"database"`
 	SSLMode        string `yaml:"sslmode"`
 	MaxConnections int    `yaml:"max_connections"`
+	// LoadStrategies runs Bulk Insert once per listed strategy: row,
+	// values (multi-row INSERT) or copy (COPY FROM STDIN).
+	LoadStrategies []string `yaml:"load_strategies"`
+}
+
+type MySQLConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	Host           string `yaml:"host"`
+	Port           int    `yaml:"port"`
+	User           string `yaml:"user"`
+	Password       string `yaml:"password"`
+	Database       string `yaml:"database"`
+	MaxConnections int    `yaml:"max_connections"`
+	Engine         string `yaml:"engine"`
 }
 
 type OracleConfig struct {
@@ -36,22 +57,325 @@ type OracleConfig struct {
 	MaxConnections   int    `yaml:"max_connections"`
 }
 
+type SQLiteConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	Path           string `yaml:"path"`
+	JournalMode    string `yaml:"journal_mode"`
+	Synchronous    string `yaml:"synchronous"`
+	BusyTimeoutMs  int    `yaml:"busy_timeout_ms"`
+	MaxConnections int    `yaml:"max_connections"`
+}
+
+// SurrealDBConfig connects to SurrealDB. SchemaMode is schemaless, the
+// default, or schemafull, which defines every field with its type.
 type SurrealDBConfig struct {
-	Enabled   bool   `yaml:"enabled"`
-	URL       string `yaml:"url"`
-	Namespace string `yaml:"namespace"`
-	Database  string `yaml:"database"`
-	User      string `yaml:"user"`
-	Password  string `yaml:"password"`
+	Enabled    bool   `yaml:"enabled"`
+	URL        string `yaml:"url"`
+	Namespace  string `yaml:"namespace"`
+	Database   string `yaml:"database"`
+	User       string `yaml:"user"`
+	Password   string `yaml:"password"`
+	SchemaMode string `yaml:"schema_mode"`
 }
 
 type BenchmarkConfig struct {
-	RecordCount          int `yaml:"record_count"`
-	BatchSize            int `yaml:"batch_size"`
-	RandomReads          int `yaml:"random_reads"`
-	Updates              int `yaml:"updates"`
-	Transactions         int `yaml:"transactions"`
-	ConcurrentGoroutines int `yaml:"concurrent_goroutines"`
+	Seed                 int64           `yaml:"seed"`
+	RecordCount          int             `yaml:"record_count"`
+	BatchSize            int             `yaml:"batch_size"`
+	BatchSizes           []int           `yaml:"batch_sizes"`
+	RandomReads          int             `yaml:"random_reads"`
+	Updates              int             `yaml:"updates"`
+	Transactions         int             `yaml:"transactions"`
+	ConcurrentGoroutines int             `yaml:"concurrent_goroutines"`
+	ConcurrencyLevels    []int           `yaml:"concurrency_levels"`
+	Mode                 string          `yaml:"mode"`
+	Duration             time.Duration   `yaml:"duration"`
+	Percentiles          []float64       `yaml:"percentiles"`
+	WarmupIterations     int             `yaml:"warmup_iterations"`
+	Iterations           int             `yaml:"iterations"`
+	SignificanceTest     string          `yaml:"significance_test"`
+	SignificanceLevel    float64         `yaml:"significance_level"`
+	OpenLoop             OpenLoopConfig  `yaml:"open_loop"`
+	Distributions        Distributions   `yaml:"distributions"`
+	Mixed                MixedConfig     `yaml:"mixed"`
+	YCSB                 YCSBConfig      `yaml:"ycsb"`
+	TPCB                 TPCBConfig      `yaml:"tpcb"`
+	TPCC                 TPCCConfig      `yaml:"tpcc"`
+	Tables               []TableConfig   `yaml:"tables"`
+	Documents            DocumentsConfig `yaml:"documents"`
+}
+
+type OpenLoopConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Rates    []float64     `yaml:"rates"`
+	Duration time.Duration `yaml:"duration"`
+	Workers  int           `yaml:"workers"`
+}
+
+// Distributions selects how each operation family picks the keys it reads or
+// writes.
+type Distributions struct {
+	RandomRead  DistributionConfig `yaml:"random_read"`
+	Update      DistributionConfig `yaml:"update"`
+	Transaction DistributionConfig `yaml:"transaction"`
+}
+
+// DistributionConfig describes a key distribution. Theta applies to zipfian
+// and latest; HotKeys and HotOps to hotspot, where a HotOps fraction of the
+// operations targets a HotKeys fraction of the keys.
+type DistributionConfig struct {
+	Type    string  `yaml:"type"`
+	Theta   float64 `yaml:"theta"`
+	HotKeys float64 `yaml:"hot_keys"`
+	HotOps  float64 `yaml:"hot_ops"`
+}
+
+func (d *DistributionConfig) setDefaults() error {
+	switch d.Type {
+	case "":
+		d.Type = "uniform"
+	case "uniform", "zipfian", "hotspot", "latest", "sequential":
+	default:
+		return fmt.Errorf("unsupported key distribution %q (expected uniform, zipfian, hotspot, latest or sequential)", d.Type)
+	}
+	if d.Theta <= 0 || d.Theta >= 1 {
+		d.Theta = 0.99
+	}
+	if d.HotKeys <= 0 || d.HotKeys > 1 {
+		d.HotKeys = 0.2
+	}
+	if d.HotOps <= 0 || d.HotOps > 1 {
+		d.HotOps = 0.8
+	}
+	return nil
+}
+
+type MixedConfig struct {
+	Enabled             bool       `yaml:"enabled"`
+	Workers             int        `yaml:"workers"`
+	OperationsPerWorker int        `yaml:"operations_per_worker"`
+	Ratios              []MixRatio `yaml:"ratios"`
+}
+
+// MixRatio weighs the operation types of a mixed workload. Weights are
+// relative, so 95/5/0 and 19/1/0 describe the same mix.
+type MixRatio struct {
+	Read   float64 `yaml:"read"`
+	Update float64 `yaml:"update"`
+	Insert float64 `yaml:"insert"`
+}
+
+func (r MixRatio) Total() float64 {
+	return r.Read + r.Update + r.Insert
+}
+
+// YCSBConfig tunes the YCSB suite. OperationCount is the number of calls per
+// workload, spread over Workers.
+type YCSBConfig struct {
+	Workloads           []string `yaml:"workloads"`
+	OperationCount      int      `yaml:"operation_count"`
+	Workers             int      `yaml:"workers"`
+	RequestDistribution string   `yaml:"request_distribution"`
+	ZipfianTheta        float64  `yaml:"zipfian_theta"`
+	MaxScanLength       int      `yaml:"max_scan_length"`
+}
+
+// TPCBConfig tunes the TPC-B suite. Scale is the number of branches, each with
+// 10 tellers and 100000 accounts.
+type TPCBConfig struct {
+	Scale        int `yaml:"scale"`
+	Clients      int `yaml:"clients"`
+	Transactions int `yaml:"transactions"`
+}
+
+// TPCCConfig tunes the TPC-C suite. Each terminal is bound to a home
+// warehouse, spreading terminals evenly over the warehouses.
+type TPCCConfig struct {
+	Warehouses   int `yaml:"warehouses"`
+	Terminals    int `yaml:"terminals"`
+	Transactions int `yaml:"transactions"`
+}
+
+// DocumentsConfig tunes the documents suite. Document sizes, in bytes of JSON,
+// are spread log-uniformly between MinSize and MaxSize. Operations is the
+// number of calls of every query and update.
+type DocumentsConfig struct {
+	Count      int `yaml:"count"`
+	MinSize    int `yaml:"min_size"`
+	MaxSize    int `yaml:"max_size"`
+	Operations int `yaml:"operations"`
+}
+
+// TableConfig describes a custom table for the tables suite. Its rows are
+// generated column by column from the description.
+type TableConfig struct {
+	Name    string         `yaml:"name"`
+	Rows    int            `yaml:"rows"`
+	Columns []ColumnConfig `yaml:"columns"`
+}
+
+// ColumnConfig describes one column of a custom table. Type is int, float,
+// string, bool or timestamp. The integer primary key numbers the rows from 1.
+// Nullable is the fraction of NULL values. A column with a Cardinality draws
+// from that many distinct values following Distribution, and References names
+// the "table.column" primary key a foreign key points to. Min and Max bound
+// numbers, MinLength and MaxLength strings. IndexName and Default are not
+// configurable; they keep the records table on its original index names and
+// column default.
+type ColumnConfig struct {
+	Name         string             `yaml:"name"`
+	Type         string             `yaml:"type"`
+	PrimaryKey   bool               `yaml:"primary_key"`
+	Nullable     float64            `yaml:"nullable"`
+	Cardinality  int                `yaml:"cardinality"`
+	Min          float64            `yaml:"min"`
+	Max          float64            `yaml:"max"`
+	MinLength    int                `yaml:"min_length"`
+	MaxLength    int                `yaml:"max_length"`
+	Distribution DistributionConfig `yaml:"distribution"`
+	References   string             `yaml:"references"`
+	Index        bool               `yaml:"index"`
+	IndexName    string             `yaml:"-"`
+	Default      any                `yaml:"-"`
+}
+
+// IndexName returns the name of the index on column.
+func (t *TableConfig) IndexName(column ColumnConfig) string {
+	if column.IndexName != "" {
+		return column.IndexName
+	}
+	return "idx_" + t.Name + "_" + column.Name
+}
+
+// PrimaryKey returns the primary key column of the table.
+func (t *TableConfig) PrimaryKey() ColumnConfig {
+	for _, column := range t.Columns {
+		if column.PrimaryKey {
+			return column
+		}
+	}
+	return ColumnConfig{}
+}
+
+// ReferencedTable returns the table a foreign key column points to.
+func (c *ColumnConfig) ReferencedTable() string {
+	table, _, _ := strings.Cut(c.References, ".")
+	return table
+}
+
+func (c *ColumnConfig) setDefaults(table string) error {
+	switch c.Type {
+	case "int", "float", "string", "bool", "timestamp":
+	default:
+		return fmt.Errorf("column %s.%s: unsupported type %q (expected int, float, string, bool or timestamp)", table, c.Name, c.Type)
+	}
+	if c.PrimaryKey && c.Type != "int" {
+		return fmt.Errorf("column %s.%s: primary key must be an int", table, c.Name)
+	}
+	if c.Nullable < 0 || c.Nullable > 1 {
+		return fmt.Errorf("column %s.%s: nullable must be a fraction between 0 and 1", table, c.Name)
+	}
+	if c.PrimaryKey && c.Nullable > 0 {
+		return fmt.Errorf("column %s.%s: primary key cannot be nullable", table, c.Name)
+	}
+	if c.Min == 0 && c.Max == 0 {
+		switch c.Type {
+		case "int":
+			c.Max = 1000000
+		case "float":
+			c.Max = 1000
+		}
+	}
+	if c.Max < c.Min {
+		return fmt.Errorf("column %s.%s: max is below min", table, c.Name)
+	}
+	if c.Type == "string" {
+		if c.MaxLength <= 0 {
+			c.MaxLength = max(c.MinLength, 32)
+		}
+		if c.MinLength <= 0 {
+			c.MinLength = min(8, c.MaxLength)
+		}
+		if c.MaxLength < c.MinLength {
+			return fmt.Errorf("column %s.%s: max_length is below min_length", table, c.Name)
+		}
+	}
+	return c.Distribution.setDefaults()
+}
+
+// orderTables validates the custom tables and sorts them so that every table
+// comes after the tables its foreign keys reference.
+func orderTables(tables []TableConfig, defaultRows int) ([]TableConfig, error) {
+	byName := make(map[string]*TableConfig, len(tables))
+	for i := range tables {
+		table := &tables[i]
+		if table.Name == "" {
+			return nil, fmt.Errorf("table %d has no name", i+1)
+		}
+		if _, exists := byName[table.Name]; exists {
+			return nil, fmt.Errorf("table %s defined twice", table.Name)
+		}
+		byName[table.Name] = table
+
+		if table.Rows <= 0 {
+			table.Rows = defaultRows
+		}
+		keys := 0
+		for j := range table.Columns {
+			column := &table.Columns[j]
+			if column.Name == "" {
+				return nil, fmt.Errorf("table %s: column %d has no name", table.Name, j+1)
+			}
+			if err := column.setDefaults(table.Name); err != nil {
+				return nil, err
+			}
+			if column.PrimaryKey {
+				keys++
+			}
+		}
+		if keys != 1 {
+			return nil, fmt.Errorf("table %s needs exactly one primary key column", table.Name)
+		}
+	}
+
+	ordered := make([]TableConfig, 0, len(tables))
+	state := make(map[string]int) // 1 while visiting, 2 once ordered
+	var visit func(table *TableConfig) error
+	visit = func(table *TableConfig) error {
+		switch state[table.Name] {
+		case 1:
+			return fmt.Errorf("table %s is part of a foreign key cycle", table.Name)
+		case 2:
+			return nil
+		}
+		state[table.Name] = 1
+		for _, column := range table.Columns {
+			if column.References == "" {
+				continue
+			}
+			target, ok := byName[column.ReferencedTable()]
+			_, key, _ := strings.Cut(column.References, ".")
+			if !ok || target.PrimaryKey().Name != key {
+				return fmt.Errorf("column %s.%s: references %q, which is not a table's primary key", table.Name, column.Name, column.References)
+			}
+			if column.Type != "int" {
+				return fmt.Errorf("column %s.%s: foreign key must be an int", table.Name, column.Name)
+			}
+			if err := visit(target); err != nil {
+				return err
+			}
+		}
+		state[table.Name] = 2
+		ordered = append(ordered, *table)
+		return nil
+	}
+	for i := range tables {
+		if err := visit(&tables[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
 }
 
 type OutputConfig struct {
@@ -71,12 +395,200 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if cfg.Benchmark.Seed == 0 {
+		cfg.Benchmark.Seed = time.Now().UnixNano()
+	}
 	if cfg.Benchmark.RecordCount == 0 {
 		cfg.Benchmark.RecordCount = 100000
 	}
 	if cfg.Benchmark.BatchSize == 0 {
 		cfg.Benchmark.BatchSize = 1000
 	}
+	for _, level := range cfg.Benchmark.ConcurrencyLevels {
+		if level <= 0 {
+			return nil, fmt.Errorf("concurrency_levels must be positive, got %d", level)
+		}
+	}
+	for _, size := range cfg.Benchmark.BatchSizes {
+		if size <= 0 {
+			return nil, fmt.Errorf("batch_sizes must be positive, got %d", size)
+		}
+	}
+	switch cfg.Benchmark.Mode {
+	case "", "count":
+		cfg.Benchmark.Mode = "count"
+	case "duration":
+		if cfg.Benchmark.Duration <= 0 {
+			cfg.Benchmark.Duration = 10 * time.Second
+		}
+	default:
+		return nil, fmt.Errorf("unsupported benchmark mode %q (expected count or duration)", cfg.Benchmark.Mode)
+	}
+	if cfg.Benchmark.OpenLoop.Enabled {
+		if len(cfg.Benchmark.OpenLoop.Rates) == 0 {
+			cfg.Benchmark.OpenLoop.Rates = []float64{100, 500, 1000, 2000, 5000}
+		}
+		for _, rate := range cfg.Benchmark.OpenLoop.Rates {
+			if rate <= 0 {
+				return nil, fmt.Errorf("open_loop.rates must be positive, got %g", rate)
+			}
+		}
+		if cfg.Benchmark.OpenLoop.Duration <= 0 {
+			cfg.Benchmark.OpenLoop.Duration = 10 * time.Second
+		}
+		if cfg.Benchmark.OpenLoop.Workers <= 0 {
+			cfg.Benchmark.OpenLoop.Workers = 64
+		}
+	}
+	for _, d := range []*DistributionConfig{
+		&cfg.Benchmark.Distributions.RandomRead,
+		&cfg.Benchmark.Distributions.Update,
+		&cfg.Benchmark.Distributions.Transaction,
+	} {
+		if err := d.setDefaults(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Benchmark.Mixed.Enabled {
+		if len(cfg.Benchmark.Mixed.Ratios) == 0 {
+			cfg.Benchmark.Mixed.Ratios = []MixRatio{
+				{Read: 95, Update: 5},
+				{Read: 80, Update: 20},
+				{Read: 50, Update: 50},
+			}
+		}
+		for _, ratio := range cfg.Benchmark.Mixed.Ratios {
+			if ratio.Read < 0 || ratio.Update < 0 || ratio.Insert < 0 || ratio.Total() == 0 {
+				return nil, fmt.Errorf("invalid mixed ratio %g/%g/%g (weights must be non-negative and not all zero)", ratio.Read, ratio.Update, ratio.Insert)
+			}
+		}
+		if cfg.Benchmark.Mixed.Workers <= 0 {
+			cfg.Benchmark.Mixed.Workers = max(cfg.Benchmark.ConcurrentGoroutines, 1)
+		}
+		if cfg.Benchmark.Mixed.OperationsPerWorker <= 0 {
+			cfg.Benchmark.Mixed.OperationsPerWorker = 1000
+		}
+	}
+	if len(cfg.Benchmark.YCSB.Workloads) == 0 {
+		cfg.Benchmark.YCSB.Workloads = []string{"A", "B", "C", "D", "E", "F"}
+	}
+	for i, w := range cfg.Benchmark.YCSB.Workloads {
+		w = strings.ToUpper(w)
+		if len(w) != 1 || w[0] < 'A' || w[0] > 'F' {
+			return nil, fmt.Errorf("unknown ycsb workload %q (expected A to F)", cfg.Benchmark.YCSB.Workloads[i])
+		}
+		cfg.Benchmark.YCSB.Workloads[i] = w
+	}
+	switch cfg.Benchmark.YCSB.RequestDistribution {
+	case "", "uniform", "zipfian", "latest":
+	default:
+		return nil, fmt.Errorf("unsupported ycsb request distribution %q (expected uniform, zipfian or latest)", cfg.Benchmark.YCSB.RequestDistribution)
+	}
+	if cfg.Benchmark.YCSB.OperationCount <= 0 {
+		cfg.Benchmark.YCSB.OperationCount = 10000
+	}
+	if cfg.Benchmark.YCSB.Workers <= 0 {
+		cfg.Benchmark.YCSB.Workers = max(cfg.Benchmark.ConcurrentGoroutines, 1)
+	}
+	if cfg.Benchmark.YCSB.ZipfianTheta <= 0 || cfg.Benchmark.YCSB.ZipfianTheta >= 1 {
+		cfg.Benchmark.YCSB.ZipfianTheta = 0.99
+	}
+	if cfg.Benchmark.YCSB.MaxScanLength <= 0 {
+		cfg.Benchmark.YCSB.MaxScanLength = 100
+	}
+	if cfg.Benchmark.TPCB.Scale <= 0 {
+		cfg.Benchmark.TPCB.Scale = 1
+	}
+	if cfg.Benchmark.TPCB.Clients <= 0 {
+		cfg.Benchmark.TPCB.Clients = max(cfg.Benchmark.ConcurrentGoroutines, 1)
+	}
+	if cfg.Benchmark.TPCB.Transactions <= 0 {
+		cfg.Benchmark.TPCB.Transactions = 10000
+	}
+	if cfg.Benchmark.TPCC.Warehouses <= 0 {
+		cfg.Benchmark.TPCC.Warehouses = 1
+	}
+	if cfg.Benchmark.TPCC.Terminals <= 0 {
+		cfg.Benchmark.TPCC.Terminals = max(cfg.Benchmark.ConcurrentGoroutines, 1)
+	}
+	if cfg.Benchmark.TPCC.Transactions <= 0 {
+		cfg.Benchmark.TPCC.Transactions = 10000
+	}
+	if cfg.Benchmark.Documents.Count <= 0 {
+		cfg.Benchmark.Documents.Count = 10000
+	}
+	if cfg.Benchmark.Documents.MinSize <= 0 {
+		cfg.Benchmark.Documents.MinSize = 1024
+	}
+	if cfg.Benchmark.Documents.MaxSize <= 0 {
+		cfg.Benchmark.Documents.MaxSize = 100 * 1024
+	}
+	if cfg.Benchmark.Documents.MaxSize < cfg.Benchmark.Documents.MinSize {
+		return nil, fmt.Errorf("documents max_size %d is below min_size %d", cfg.Benchmark.Documents.MaxSize, cfg.Benchmark.Documents.MinSize)
+	}
+	if cfg.Benchmark.Documents.Operations <= 0 {
+		cfg.Benchmark.Documents.Operations = 1000
+	}
+	tables, err := orderTables(cfg.Benchmark.Tables, cfg.Benchmark.RecordCount)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Benchmark.Tables = tables
+	if cfg.Benchmark.Iterations <= 0 {
+		cfg.Benchmark.Iterations = 1
+	}
+	if cfg.Benchmark.WarmupIterations < 0 {
+		cfg.Benchmark.WarmupIterations = 0
+	}
+	switch cfg.Benchmark.SignificanceTest {
+	case "":
+		cfg.Benchmark.SignificanceTest = stats.TestWelch
+	case stats.TestWelch, stats.TestMannWhitney:
+	default:
+		return nil, fmt.Errorf("unsupported significance_test %q (expected %s or %s)", cfg.Benchmark.SignificanceTest, stats.TestWelch, stats.TestMannWhitney)
+	}
+	if cfg.Benchmark.SignificanceLevel <= 0 || cfg.Benchmark.SignificanceLevel >= 1 {
+		cfg.Benchmark.SignificanceLevel = 0.05
+	}
+	if len(cfg.Benchmark.Percentiles) == 0 {
+		cfg.Benchmark.Percentiles = metrics.DefaultPercentiles
+	}
+	for _, p := range cfg.Benchmark.Percentiles {
+		if p <= 0 || p > 100 {
+			return nil, fmt.Errorf("percentiles must be in (0, 100], got %g", p)
+		}
+	}
+	switch strings.ToLower(cfg.Databases.MySQL.Engine) {
+	case "", "innodb":
+		cfg.Databases.MySQL.Engine = "InnoDB"
+	case "myisam":
+		cfg.Databases.MySQL.Engine = "MyISAM"
+	case "aria":
+		cfg.Databases.MySQL.Engine = "Aria"
+	default:
+		return nil, fmt.Errorf("unsupported mysql engine %q (expected InnoDB, MyISAM or Aria)", cfg.Databases.MySQL.Engine)
+	}
+	for i, strategy := range cfg.Databases.Postgres.LoadStrategies {
+		strategy = strings.ToLower(strategy)
+		if strategy != "row" && strategy != "values" && strategy != "copy" {
+			return nil, fmt.Errorf("unsupported postgres load strategy %q (expected row, values or copy)", strategy)
+		}
+		cfg.Databases.Postgres.LoadStrategies[i] = strategy
+	}
+	switch strings.ToLower(cfg.Databases.SurrealDB.SchemaMode) {
+	case "", "schemaless":
+		cfg.Databases.SurrealDB.SchemaMode = "schemaless"
+	case "schemafull":
+		cfg.Databases.SurrealDB.SchemaMode = "schemafull"
+	default:
+		return nil, fmt.Errorf("unsupported surrealdb schema mode %q (expected schemaless or schemafull)", cfg.Databases.SurrealDB.SchemaMode)
+	}
+	if cfg.Databases.SQLite.Path == "" {
+		cfg.Databases.SQLite.Path = "dbcompare.sqlite"
+	}
+	if cfg.Databases.SQLite.BusyTimeoutMs == 0 {
+		cfg.Databases.SQLite.BusyTimeoutMs = 5000
+	}
 	if cfg.Output.Directory == "" {
 		cfg.Output.Directory = "./results"
 	}
@@ -91,3 +603,26 @@ func (c *PostgresConfig) ConnectionString() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		c.Host, c.Port, c.User, c.Password, c.Database, c.SSLMode)
 }
+
+func (c *MySQLConfig) ConnectionString() string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
+		c.User, c.Password, c.Host, c.Port, c.Database)
+}
+
+// DSN applies the pragmas to every pooled connection through the
+// modernc.org/sqlite _pragma parameters. Transactions begin IMMEDIATE, so
+// concurrent writers queue on the busy timeout instead of failing with
+// SQLITE_BUSY when a deferred transaction tries to upgrade its lock; read-only
+// transactions still begin deferred.
+func (c *SQLiteConfig) DSN() string {
+	params := url.Values{}
+	params.Set("_txlock", "immediate")
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", c.BusyTimeoutMs))
+	if c.JournalMode != "" {
+		params.Add("_pragma", fmt.Sprintf("journal_mode(%s)", c.JournalMode))
+	}
+	if c.Synchronous != "" {
+		params.Add("_pragma", fmt.Sprintf("synchronous(%s)", c.Synchronous))
+	}
+	return fmt.Sprintf("file:%s?%s", c.Path, params.Encode())
+}
@@ -0,0 +1,19 @@
+package database
+
+import "github.com/nadmax/dbcompare/internal/models"
+
+// Bulk-load strategies. LoadRow is the prepared-statement path of Insert.
+const (
+	LoadRow    = "row"
+	LoadValues = "values"
+	LoadCopy   = "copy"
+)
+
+// BulkLoader is implemented by engines offering several ways to load the
+// benchmark records. Bulk Insert then runs once per configured strategy.
+type BulkLoader interface {
+	LoadStrategies() []string
+	// InsertWith inserts records with the given strategy and, like Insert,
+	// returns the number of records that failed.
+	InsertWith(strategy string, records []models.TestRecord) (int, error)
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+// DocumentDriver is implemented by engines that can run the documents suite,
+// which stores nested documents and queries and updates them by path.
+type DocumentDriver interface {
+	CreateDocumentSchema() error
+	InsertDocuments(docs []models.Document) (int, error)
+	// QueryDocumentsByCity filters on the nested profile.address.city path.
+	QueryDocumentsByCity(city string, limit int) (int, error)
+	// QueryDocumentsByTag filters on membership of the profile.preferences.tags
+	// array.
+	QueryDocumentsByTag(tag string, limit int) (int, error)
+	// UpdateDocumentTier sets the nested profile.preferences.tier field.
+	UpdateDocumentTier(id int, tier string) error
+	// AppendDocumentEvent appends an event to the events array.
+	AppendDocumentEvent(id int, event models.DocumentEvent) error
+}
+
+// documentQueries holds the documents statements of a database/sql engine,
+// which stores each document as JSON text in a body column. cityArg and tagArg
+// turn a filter value into the query argument, such as a JSONB containment
+// document.
+type documentQueries struct {
+	schema      []string
+	insert      string
+	queryByCity string
+	queryByTag  string
+	updateTier  string
+	appendEvent string
+	cityArg     func(city string) any
+	tagArg      func(tag string) any
+}
+
+func (s *sqlDriver) CreateDocumentSchema() error {
+	if s.documents == nil {
+		return ErrUnsupported
+	}
+	return s.execAll(s.documents.schema)
+}
+
+func (s *sqlDriver) InsertDocuments(docs []models.Document) (int, error) {
+	if s.documents == nil {
+		return 0, ErrUnsupported
+	}
+
+	failed := 0
+	err := s.inTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(s.documents.insert)
+		if err != nil {
+			return err
+		}
+		defer closeStmt(stmt)
+
+		for _, doc := range docs {
+			body, err := json.Marshal(doc)
+			if err != nil {
+				return err
+			}
+			if _, err := stmt.Exec(doc.ID, string(body)); err != nil {
+				failed++
+			}
+		}
+		return nil
+	})
+	return failed, err
+}
+
+func (s *sqlDriver) QueryDocumentsByCity(city string, limit int) (int, error) {
+	if s.documents == nil {
+		return 0, ErrUnsupported
+	}
+
+	var arg any = city
+	if s.documents.cityArg != nil {
+		arg = s.documents.cityArg(city)
+	}
+	rows, err := s.db.Query(s.documents.queryByCity, arg, limit)
+	if err != nil {
+		return 0, err
+	}
+	return drainRows(rows)
+}
+
+func (s *sqlDriver) QueryDocumentsByTag(tag string, limit int) (int, error) {
+	if s.documents == nil {
+		return 0, ErrUnsupported
+	}
+
+	var arg any = tag
+	if s.documents.tagArg != nil {
+		arg = s.documents.tagArg(tag)
+	}
+	rows, err := s.db.Query(s.documents.queryByTag, arg, limit)
+	if err != nil {
+		return 0, err
+	}
+	return drainRows(rows)
+}
+
+func (s *sqlDriver) UpdateDocumentTier(id int, tier string) error {
+	if s.documents == nil {
+		return ErrUnsupported
+	}
+
+	_, err := s.db.Exec(s.documents.updateTier, tier, id)
+	return err
+}
+
+func (s *sqlDriver) AppendDocumentEvent(id int, event models.DocumentEvent) error {
+	if s.documents == nil {
+		return ErrUnsupported
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(s.documents.appendEvent, string(body), id)
+	return err
+}
+
+// jsonArg encodes v as a JSON text argument.
+func jsonArg(v any) any {
+	body, _ := json.Marshal(v)
+	return string(body)
+}
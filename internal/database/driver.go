@@ -0,0 +1,71 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/nadmax/dbcompare/internal/config"
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+// ErrUnsupported is returned by drivers for operations the engine cannot run.
+// The benchmark catalog skips such operations instead of reporting failures.
+var ErrUnsupported = errors.New("operation not supported")
+
+type Driver interface {
+	Name() string
+	CreateSchema() error
+	TruncateTable() error
+	Insert(records []models.TestRecord) (int, error)
+	ReadByID(id int) error
+	Scan(limit int) (int, error)
+	// ScanRange reads up to count records in insertion order, starting at the
+	// startID-th record.
+	ScanRange(startID, count int) (int, error)
+	QueryByAge(age, limit int) error
+	UpdateBalance(id int, balance float64) error
+	Aggregate() (int, error)
+	Transfer(fromID, toID int, amount float64) error
+	// Execute runs a statement written in the engine's own query language.
+	// SQL engines bind args positionally, SurrealDB binds them by name.
+	Execute(query string, args []sql.NamedArg) (int, error)
+	Close() error
+}
+
+type Registration struct {
+	Name    string
+	Enabled func(cfg *config.Config) bool
+	Open    func(cfg *config.Config) (Driver, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Registration)
+)
+
+func Register(reg Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[reg.Name]; exists {
+		panic(fmt.Sprintf("database: driver %q registered twice", reg.Name))
+	}
+	registry[reg.Name] = reg
+}
+
+func Registered() []Registration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	regs := make([]Registration, 0, len(registry))
+	for _, reg := range registry {
+		regs = append(regs, reg)
+	}
+	sort.Slice(regs, func(i, j int) bool {
+		return regs[i].Name < regs[j].Name
+	})
+	return regs
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"fmt"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/nadmax/dbcompare/internal/config"
+)
+
+func init() {
+	Register(Registration{
+		Name: "mysql",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Databases.MySQL.Enabled
+		},
+		Open: func(cfg *config.Config) (Driver, error) {
+			return NewMySQLDB(&cfg.Databases.MySQL)
+		},
+	})
+}
+
+type MySQLDB struct {
+	sqlDriver
+	config *config.MySQLConfig
+}
+
+func NewMySQLDB(cfg *config.MySQLConfig) (*MySQLDB, error) {
+	db, err := openSQL("mysql", cfg.ConnectionString(), cfg.MaxConnections)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MySQLDB{
+		sqlDriver: sqlDriver{
+			db:      db,
+			name:    fmt.Sprintf("MySQL (%s)", cfg.Engine),
+			queries: mysqlQueries,
+			tpcc:    newTPCCQueries(" ENGINE="+cfg.Engine, nil),
+			tables: &tableDialect{
+				types:        mysqlTableTypes,
+				tableOptions: " ENGINE=" + cfg.Engine,
+				dropTable:    dropTableIfExists,
+			},
+		},
+		config: cfg,
+	}, nil
+}
+
+func (m *MySQLDB) GetStats() (map[string]any, error) {
+	stats := make(map[string]any)
+
+	var count int64
+	if err := m.db.QueryRow("SELECT COUNT(*) FROM benchmark_records").Scan(&count); err != nil {
+		return nil, err
+	}
+	stats["row_count"] = count
+
+	var engine string
+	var dataLength, indexLength int64
+	err := m.db.QueryRow(`
+		SELECT engine, data_length, index_length
+		FROM information_schema.tables
+		WHERE table_schema = DATABASE() AND table_name = 'benchmark_records'
+	`).Scan(&engine, &dataLength, &indexLength)
+	if err != nil {
+		return nil, err
+	}
+	stats["engine"] = engine
+	stats["data_length"] = dataLength
+	stats["index_length"] = indexLength
+
+	return stats, nil
+}
+
+var mysqlTableTypes = map[string]string{
+	"serial":    "INT AUTO_INCREMENT",
+	"int":       "BIGINT",
+	"int32":     "INT",
+	"float":     "DOUBLE",
+	"decimal":   "DECIMAL(10,2)",
+	"string":    "VARCHAR(%d)",
+	"text":      "TEXT",
+	"bool":      "BOOLEAN",
+	"timestamp": "DATETIME(6)",
+}
+
+// mysqlQueries runs the shared records table on the chosen storage engine.
+// Transfers still run inside BEGIN/COMMIT, which non-transactional engines
+// such as MyISAM and Aria silently treat as autocommit.
+var mysqlQueries = sqlQueries{
+	truncate: []string{
+		`TRUNCATE TABLE benchmark_records`,
+	},
+	insert: `
+		INSERT INTO benchmark_records (name, email, age, balance, created_at, description, is_active)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+	readByID:      `SELECT * FROM benchmark_records WHERE id = ?`,
+	scan:          `SELECT * FROM benchmark_records LIMIT ?`,
+	scanRange:     `SELECT * FROM benchmark_records WHERE id >= ? ORDER BY id LIMIT ?`,
+	queryByAge:    `SELECT * FROM benchmark_records WHERE age = ? LIMIT ?`,
+	updateBalance: `UPDATE benchmark_records SET balance = ? WHERE id = ?`,
+	aggregate: `
+		SELECT
+			age,
+			COUNT(*) as user_count,
+			AVG(balance) as avg_balance,
+			MAX(balance) as max_balance,
+			MIN(balance) as min_balance
+		FROM benchmark_records
+		WHERE is_active = true AND age > 25
+		GROUP BY age
+		HAVING COUNT(*) > 5
+		ORDER BY avg_balance DESC
+		LIMIT 50
+	`,
+	debit:  `UPDATE benchmark_records SET balance = balance - ? WHERE id = ?`,
+	credit: `UPDATE benchmark_records SET balance = balance + ? WHERE id = ?`,
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"github.com/nadmax/dbcompare/internal/config"
+	_ "github.com/sijms/go-ora/v2"
+)
+
+func init() {
+	Register(Registration{
+		Name: "oracle",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Databases.Oracle.Enabled
+		},
+		Open: func(cfg *config.Config) (Driver, error) {
+			return NewOracleDB(&cfg.Databases.Oracle)
+		},
+	})
+}
+
+type OracleDB struct {
+	sqlDriver
+	config *config.OracleConfig
+}
+
+func NewOracleDB(cfg *config.OracleConfig) (*OracleDB, error) {
+	db, err := openSQL("oracle", cfg.ConnectionString, cfg.MaxConnections)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OracleDB{
+		sqlDriver: sqlDriver{
+			db:      db,
+			name:    "Oracle",
+			queries: oracleQueries,
+			tables: &tableDialect{
+				types:        oracleTableTypes,
+				numericBools: true,
+				dropTable:    oracleDropTable,
+				rebind:       colonPlaceholders,
+			},
+		},
+		config: cfg,
+	}, nil
+}
+
+// Oracle has no boolean column type before 23ai, so is_active is stored as
+// NUMBER(1) to keep the schema portable across releases.
+var oracleQueries = sqlQueries{
+	truncate: []string{
+		`TRUNCATE TABLE benchmark_records`,
+		`ALTER TABLE benchmark_records MODIFY id GENERATED BY DEFAULT AS IDENTITY (START WITH 1)`,
+	},
+	insert: `
+		INSERT INTO benchmark_records (name, email, age, balance, created_at, description, is_active)
+		VALUES (:1, :2, :3, :4, :5, :6, :7)
+	`,
+	readByID:      `SELECT * FROM benchmark_records WHERE id = :1`,
+	scan:          `SELECT * FROM benchmark_records FETCH FIRST :1 ROWS ONLY`,
+	scanRange:     `SELECT * FROM benchmark_records WHERE id >= :1 ORDER BY id FETCH FIRST :2 ROWS ONLY`,
+	queryByAge:    `SELECT * FROM benchmark_records WHERE age = :1 FETCH FIRST :2 ROWS ONLY`,
+	updateBalance: `UPDATE benchmark_records SET balance = :1 WHERE id = :2`,
+	aggregate: `
+		SELECT
+			age,
+			COUNT(*) as user_count,
+			AVG(balance) as avg_balance,
+			MAX(balance) as max_balance,
+			MIN(balance) as min_balance
+		FROM benchmark_records
+		WHERE is_active = 1 AND age > 25
+		GROUP BY age
+		HAVING COUNT(*) > 5
+		ORDER BY avg_balance DESC
+		FETCH FIRST 50 ROWS ONLY
+	`,
+	debit:  `UPDATE benchmark_records SET balance = balance - :1 WHERE id = :2`,
+	credit: `UPDATE benchmark_records SET balance = balance + :1 WHERE id = :2`,
+	boolArg: func(v bool) any {
+		if v {
+			return 1
+		}
+		return 0
+	},
+}
+
+var oracleTableTypes = map[string]string{
+	"serial":    "NUMBER GENERATED BY DEFAULT AS IDENTITY",
+	"int":       "NUMBER(19)",
+	"int32":     "NUMBER(10)",
+	"float":     "BINARY_DOUBLE",
+	"decimal":   "NUMBER(10,2)",
+	"string":    "VARCHAR2(%d)",
+	"text":      "VARCHAR2(4000)",
+	"bool":      "NUMBER(1)",
+	"timestamp": "TIMESTAMP",
+}
+
+func oracleDropTable(table string) string {
+	return `BEGIN
+		EXECUTE IMMEDIATE 'DROP TABLE ` + table + ` CASCADE CONSTRAINTS PURGE';
+	EXCEPTION
+		WHEN OTHERS THEN
+			IF SQLCODE != -942 THEN
+				RAISE;
+			END IF;
+	END;`
+}
@@ -3,80 +3,165 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/nadmax/dbcompare/internal/config"
+	"github.com/nadmax/dbcompare/internal/models"
 )
 
+func init() {
+	Register(Registration{
+		Name: "postgres",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Databases.Postgres.Enabled
+		},
+		Open: func(cfg *config.Config) (Driver, error) {
+			return NewPostgresDB(&cfg.Databases.Postgres)
+		},
+	})
+}
+
 type PostgresDB struct {
-	db     *sql.DB
+	sqlDriver
 	config *config.PostgresConfig
 }
 
 func NewPostgresDB(cfg *config.PostgresConfig) (*PostgresDB, error) {
-	connStr := cfg.ConnectionString()
-
-	db, err := sql.Open("postgres", connStr)
+	db, err := openSQL("postgres", cfg.ConnectionString(), cfg.MaxConnections)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open connection: %w", err)
-	}
-
-	db.SetMaxOpenConns(cfg.MaxConnections)
-	db.SetMaxIdleConns(cfg.MaxConnections / 5)
-
-	if err := db.Ping(); err != nil {
-		if closeErr := db.Close(); closeErr != nil {
-			return nil, fmt.Errorf("failed to ping database: %w (also failed to close: %v)", err, closeErr)
-		}
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		return nil, err
 	}
 
 	return &PostgresDB{
-		db:     db,
+		sqlDriver: sqlDriver{
+			db:      db,
+			name:    "PostgreSQL",
+			queries: postgresQueries,
+			tpcb:    &postgresTPCBQueries,
+			tpcc:    newTPCCQueries("", dollarPlaceholders),
+			tables: &tableDialect{
+				types:     withTypes(standardTableTypes, map[string]string{"serial": "SERIAL"}),
+				dropTable: func(table string) string { return "DROP TABLE IF EXISTS " + table + " CASCADE" },
+				rebind:    dollarPlaceholders,
+			},
+			documents: &postgresDocumentQueries,
+		},
 		config: cfg,
 	}, nil
 }
 
-func (p *PostgresDB) DB() *sql.DB {
-	return p.db
+var postgresQueries = sqlQueries{
+	truncate: []string{
+		`TRUNCATE TABLE benchmark_records RESTART IDENTITY CASCADE`,
+	},
+	insert: `
+		INSERT INTO benchmark_records (name, email, age, balance, created_at, description, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+	readByID:      `SELECT * FROM benchmark_records WHERE id = $1`,
+	scan:          `SELECT * FROM benchmark_records LIMIT $1`,
+	scanRange:     `SELECT * FROM benchmark_records WHERE id >= $1 ORDER BY id LIMIT $2`,
+	queryByAge:    `SELECT * FROM benchmark_records WHERE age = $1 LIMIT $2`,
+	updateBalance: `UPDATE benchmark_records SET balance = $1 WHERE id = $2`,
+	aggregate: `
+		SELECT 
+			age,
+			COUNT(*) as user_count,
+			AVG(balance) as avg_balance,
+			MAX(balance) as max_balance,
+			MIN(balance) as min_balance
+		FROM benchmark_records
+		WHERE is_active = true AND age > 25
+		GROUP BY age
+		HAVING COUNT(*) > 5
+		ORDER BY avg_balance DESC
+		LIMIT 50
+	`,
+	debit:  `UPDATE benchmark_records SET balance = balance - $1 WHERE id = $2`,
+	credit: `UPDATE benchmark_records SET balance = balance + $1 WHERE id = $2`,
 }
 
-func (p *PostgresDB) Close() error {
-	return p.db.Close()
+// recordColumns are the benchmark_records columns set on insert, in the order
+// of recordValues.
+var recordColumns = insertColumns(recordsTable)
+
+// postgresValuesRows keeps a multi-row INSERT within the 65535 bind
+// parameters PostgreSQL accepts per statement.
+var postgresValuesRows = 65535 / len(recordColumns)
+
+func recordValues(record models.TestRecord) []any {
+	return []any{record.Name, record.Email, record.Age, record.Balance, record.CreatedAt, record.Description, record.IsActive}
 }
 
-func (p *PostgresDB) CreateSchema() error {
-	queries := []string{
-		`DROP TABLE IF EXISTS benchmark_records CASCADE`,
-		`CREATE TABLE benchmark_records (
-			id SERIAL PRIMARY KEY,
-			name VARCHAR(100) NOT NULL,
-			email VARCHAR(100) NOT NULL,
-			age INTEGER NOT NULL,
-			balance DECIMAL(10,2) NOT NULL,
-			created_at TIMESTAMP NOT NULL,
-			description TEXT,
-			is_active BOOLEAN NOT NULL DEFAULT true
-		)`,
-		`CREATE INDEX idx_email ON benchmark_records(email)`,
-		`CREATE INDEX idx_age ON benchmark_records(age)`,
-		`CREATE INDEX idx_balance ON benchmark_records(balance)`,
-		`CREATE INDEX idx_created_at ON benchmark_records(created_at)`,
-		`CREATE INDEX idx_active ON benchmark_records(is_active)`,
-	}
+func (p *PostgresDB) LoadStrategies() []string {
+	return p.config.LoadStrategies
+}
 
-	for _, query := range queries {
-		if _, err := p.db.Exec(query); err != nil {
-			return fmt.Errorf("failed to execute query: %w", err)
-		}
+func (p *PostgresDB) InsertWith(strategy string, records []models.TestRecord) (int, error) {
+	switch strategy {
+	case LoadRow:
+		return p.Insert(records)
+	case LoadValues:
+		return 0, p.insertValues(records)
+	case LoadCopy:
+		return 0, p.copyIn(records)
+	default:
+		return 0, fmt.Errorf("unsupported load strategy %q", strategy)
 	}
+}
 
-	return nil
+// insertValues sends the batch as multi-row INSERT statements in one
+// transaction.
+func (p *PostgresDB) insertValues(records []models.TestRecord) error {
+	return p.inTx(func(tx *sql.Tx) error {
+		for start := 0; start < len(records); start += postgresValuesRows {
+			chunk := records[start:min(start+postgresValuesRows, len(records))]
+
+			var query strings.Builder
+			query.WriteString("INSERT INTO benchmark_records (" + strings.Join(recordColumns, ", ") + ") VALUES ")
+			args := make([]any, 0, len(chunk)*len(recordColumns))
+			for i, record := range chunk {
+				if i > 0 {
+					query.WriteString(", ")
+				}
+				query.WriteString("(")
+				for j := range recordColumns {
+					if j > 0 {
+						query.WriteString(", ")
+					}
+					fmt.Fprintf(&query, "$%d", len(args)+j+1)
+				}
+				query.WriteString(")")
+				args = append(args, recordValues(record)...)
+			}
+
+			if _, err := tx.Exec(query.String(), args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func (p *PostgresDB) TruncateTable() error {
-	_, err := p.db.Exec("TRUNCATE TABLE benchmark_records RESTART IDENTITY CASCADE")
-	return err
+// copyIn streams the batch with COPY FROM STDIN in one transaction. A bad row
+// aborts the whole COPY, so failures are reported for the batch.
+func (p *PostgresDB) copyIn(records []models.TestRecord) error {
+	return p.inTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(pq.CopyIn("benchmark_records", recordColumns...))
+		if err != nil {
+			return err
+		}
+		defer closeStmt(stmt)
+
+		for _, record := range records {
+			if _, err := stmt.Exec(recordValues(record)...); err != nil {
+				return err
+			}
+		}
+		_, err = stmt.Exec()
+		return err
+	})
 }
 
 func (p *PostgresDB) GetStats() (map[string]any, error) {
@@ -124,3 +209,72 @@ func (p *PostgresDB) GetStats() (map[string]any, error) {
 
 	return stats, nil
 }
+
+var postgresTPCBQueries = tpcbQueries{
+	schema: []string{
+		`DROP TABLE IF EXISTS tpcb_history, tpcb_accounts, tpcb_tellers, tpcb_branches CASCADE`,
+		`CREATE TABLE tpcb_branches (
+			bid INTEGER PRIMARY KEY,
+			bbalance BIGINT NOT NULL,
+			filler CHAR(88)
+		)`,
+		`CREATE TABLE tpcb_tellers (
+			tid INTEGER PRIMARY KEY,
+			bid INTEGER NOT NULL,
+			tbalance BIGINT NOT NULL,
+			filler CHAR(84)
+		)`,
+		`CREATE TABLE tpcb_accounts (
+			aid INTEGER PRIMARY KEY,
+			bid INTEGER NOT NULL,
+			abalance BIGINT NOT NULL,
+			filler CHAR(84)
+		)`,
+		`CREATE TABLE tpcb_history (
+			tid INTEGER NOT NULL,
+			bid INTEGER NOT NULL,
+			aid INTEGER NOT NULL,
+			delta BIGINT NOT NULL,
+			mtime TIMESTAMP NOT NULL,
+			filler CHAR(22)
+		)`,
+	},
+	populate: []string{
+		`INSERT INTO tpcb_branches (bid, bbalance)
+			SELECT bid, 0 FROM generate_series(1, $1) AS bid`,
+		`INSERT INTO tpcb_tellers (tid, bid, tbalance)
+			SELECT tid, (tid - 1) / 10 + 1, 0 FROM generate_series(1, $1 * 10) AS tid`,
+		`INSERT INTO tpcb_accounts (aid, bid, abalance, filler)
+			SELECT aid, (aid - 1) / 100000 + 1, 0, '' FROM generate_series(1, $1 * 100000) AS aid`,
+	},
+	updateAccount: `UPDATE tpcb_accounts SET abalance = abalance + $1 WHERE aid = $2`,
+	selectAccount: `SELECT abalance FROM tpcb_accounts WHERE aid = $1`,
+	updateTeller:  `UPDATE tpcb_tellers SET tbalance = tbalance + $1 WHERE tid = $2`,
+	updateBranch:  `UPDATE tpcb_branches SET bbalance = bbalance + $1 WHERE bid = $2`,
+	insertHistory: `INSERT INTO tpcb_history (tid, bid, aid, delta, mtime) VALUES ($1, $2, $3, $4, $5)`,
+	totals:        tpcbTotalsQuery,
+}
+
+// postgresDocumentQueries stores documents as JSONB under a jsonb_path_ops GIN
+// index, which serves both path filters as containment queries.
+var postgresDocumentQueries = documentQueries{
+	schema: []string{
+		`DROP TABLE IF EXISTS documents`,
+		`CREATE TABLE documents (
+			id INTEGER PRIMARY KEY,
+			body JSONB NOT NULL
+		)`,
+		`CREATE INDEX idx_documents_body ON documents USING GIN (body jsonb_path_ops)`,
+	},
+	insert:      `INSERT INTO documents (id, body) VALUES ($1, $2)`,
+	queryByCity: `SELECT id, body FROM documents WHERE body @> $1::jsonb LIMIT $2`,
+	queryByTag:  `SELECT id, body FROM documents WHERE body @> $1::jsonb LIMIT $2`,
+	updateTier:  `UPDATE documents SET body = jsonb_set(body, '{profile,preferences,tier}', to_jsonb($1::text)) WHERE id = $2`,
+	appendEvent: `UPDATE documents SET body = jsonb_set(body, '{events}', (body->'events') || jsonb_build_array($1::jsonb)) WHERE id = $2`,
+	cityArg: func(city string) any {
+		return jsonArg(map[string]any{"profile": map[string]any{"address": map[string]any{"city": city}}})
+	},
+	tagArg: func(tag string) any {
+		return jsonArg(map[string]any{"profile": map[string]any{"preferences": map[string]any{"tags": []string{tag}}}})
+	},
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/nadmax/dbcompare/internal/config"
+)
+
+// recordsTable describes the benchmark records table in the same terms as the
+// custom tables. Every engine builds its records schema from it: the SQL
+// engines through their table dialect and SurrealDB through surrealSchema.
+// Besides the custom-table types it uses serial for a key the engine
+// generates, int32 for a 32-bit integer, decimal for a two-digit fixed-point
+// number and text for an unbounded string. The SQL rendering matches the
+// hand-written schemas the engines had before, index names included, so
+// results stay comparable with earlier runs. description is NULL-able in the
+// DDL only; generated records always set it.
+var recordsTable = config.TableConfig{
+	Name: "benchmark_records",
+	Columns: []config.ColumnConfig{
+		{Name: "id", Type: "serial", PrimaryKey: true},
+		{Name: "name", Type: "string", MaxLength: 100},
+		{Name: "email", Type: "string", MaxLength: 100, Index: true, IndexName: "idx_email"},
+		{Name: "age", Type: "int32", Index: true, IndexName: "idx_age"},
+		{Name: "balance", Type: "decimal", Index: true, IndexName: "idx_balance"},
+		{Name: "created_at", Type: "timestamp", Index: true, IndexName: "idx_created_at"},
+		{Name: "description", Type: "text", Nullable: 1},
+		{Name: "is_active", Type: "bool", Index: true, IndexName: "idx_active", Default: true},
+	},
+}
+
+// recordsSchema returns the statements recreating the records table.
+func (d *tableDialect) recordsSchema() []string {
+	return append([]string{d.dropTable(recordsTable.Name)}, d.createTable(recordsTable)...)
+}
+
+// insertColumns returns the names of the columns an insert sets, leaving out
+// serial keys.
+func insertColumns(table config.TableConfig) []string {
+	names := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		if column.Type != "serial" {
+			names = append(names, column.Name)
+		}
+	}
+	return names
+}
+
+// withTypes returns the types of base extended with extra.
+func withTypes(base, extra map[string]string) map[string]string {
+	types := make(map[string]string, len(base)+len(extra))
+	maps.Copy(types, base)
+	maps.Copy(types, extra)
+	return types
+}
+
+const (
+	SurrealSchemaless = "schemaless"
+	SurrealSchemafull = "schemafull"
+)
+
+var surrealTypes = map[string]string{
+	"serial":    "int",
+	"int":       "int",
+	"int32":     "int",
+	"float":     "float",
+	"decimal":   "float",
+	"string":    "string",
+	"text":      "string",
+	"bool":      "bool",
+	"timestamp": "datetime",
+}
+
+// surrealSchema returns the statements recreating table in the given schema
+// mode. Schemaless tables only get their indexes; schemafull ones also define
+// every field with its type and default, NULL allowed for nullable columns and a length
+// assertion mirroring VARCHAR limits. The primary key maps to the record ID.
+func surrealSchema(table config.TableConfig, mode string) []string {
+	statements := []string{"REMOVE TABLE IF EXISTS " + table.Name}
+	if mode == SurrealSchemafull {
+		statements = append(statements, "DEFINE TABLE "+table.Name+" SCHEMAFULL")
+		for _, column := range table.Columns {
+			if column.PrimaryKey {
+				continue
+			}
+			statement := fmt.Sprintf("DEFINE FIELD %s ON TABLE %s TYPE %s", column.Name, table.Name, surrealTypes[column.Type])
+			if column.Nullable > 0 {
+				statement += " | null"
+			}
+			if column.Default != nil {
+				statement += fmt.Sprintf(" DEFAULT %v", column.Default)
+			}
+			if column.Type == "string" && column.MaxLength > 0 {
+				assert := fmt.Sprintf("string::len($value) <= %d", column.MaxLength)
+				if column.Nullable > 0 {
+					assert = "$value = NULL OR " + assert
+				}
+				statement += " ASSERT " + assert
+			}
+			statements = append(statements, statement)
+		}
+	} else {
+		statements = append(statements, "DEFINE TABLE "+table.Name+" SCHEMALESS")
+	}
+
+	for _, column := range table.Columns {
+		if column.Index {
+			statements = append(statements, fmt.Sprintf("DEFINE INDEX %s ON TABLE %s FIELDS %s", table.IndexName(column), table.Name, column.Name))
+		}
+	}
+	return statements
+}
@@ -0,0 +1,251 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+// sqlQueries holds the dialect-specific statements of a database/sql engine.
+// Placeholders follow the engine's own syntax. The records table itself is
+// created from recordsTable through the engine's table dialect.
+type sqlQueries struct {
+	truncate      []string
+	insert        string
+	readByID      string
+	scan          string
+	scanRange     string
+	queryByAge    string
+	updateBalance string
+	aggregate     string
+	debit         string
+	credit        string
+	boolArg       func(bool) any
+}
+
+// sqlDriver implements the Driver operations shared by every database/sql
+// engine. Engines embed it and provide their statements through sqlQueries.
+type sqlDriver struct {
+	db        *sql.DB
+	name      string
+	queries   sqlQueries
+	tpcb      *tpcbQueries
+	tpcc      *tpccQueries
+	tables    *tableDialect
+	documents *documentQueries
+}
+
+func openSQL(driverName, dsn string, maxConnections int) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open connection: %w", err)
+	}
+
+	if maxConnections > 0 {
+		db.SetMaxOpenConns(maxConnections)
+		db.SetMaxIdleConns(max(maxConnections/5, 1))
+	}
+
+	if err := db.Ping(); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			return nil, fmt.Errorf("failed to ping database: %w (also failed to close: %v)", err, closeErr)
+		}
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return db, nil
+}
+
+func (s *sqlDriver) Name() string {
+	return s.name
+}
+
+func (s *sqlDriver) DB() *sql.DB {
+	return s.db
+}
+
+func (s *sqlDriver) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqlDriver) CreateSchema() error {
+	return s.execAll(s.tables.recordsSchema())
+}
+
+func (s *sqlDriver) TruncateTable() error {
+	return s.execAll(s.queries.truncate)
+}
+
+func (s *sqlDriver) execAll(queries []string) error {
+	for _, query := range queries {
+		if _, err := s.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute query: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *sqlDriver) boolArg(v bool) any {
+	if s.queries.boolArg != nil {
+		return s.queries.boolArg(v)
+	}
+	return v
+}
+
+func (s *sqlDriver) Insert(records []models.TestRecord) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			fmt.Printf("Warning: failed to rollback transaction: %v\n", err)
+		}
+	}()
+
+	stmt, err := tx.Prepare(s.queries.insert)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			fmt.Printf("Warning: failed to close statement: %v\n", err)
+		}
+	}()
+
+	failed := 0
+	for _, record := range records {
+		_, err := stmt.Exec(
+			record.Name,
+			record.Email,
+			record.Age,
+			record.Balance,
+			record.CreatedAt,
+			record.Description,
+			s.boolArg(record.IsActive),
+		)
+		if err != nil {
+			failed++
+		}
+	}
+
+	return failed, tx.Commit()
+}
+
+func (s *sqlDriver) ReadByID(id int) error {
+	rows, err := s.db.Query(s.queries.readByID, id)
+	if err != nil {
+		return err
+	}
+	_, err = drainRows(rows)
+	return err
+}
+
+func (s *sqlDriver) Scan(limit int) (int, error) {
+	rows, err := s.db.Query(s.queries.scan, limit)
+	if err != nil {
+		return 0, err
+	}
+	return drainRows(rows)
+}
+
+func (s *sqlDriver) ScanRange(startID, count int) (int, error) {
+	rows, err := s.db.Query(s.queries.scanRange, startID, count)
+	if err != nil {
+		return 0, err
+	}
+	return drainRows(rows)
+}
+
+func (s *sqlDriver) QueryByAge(age, limit int) error {
+	rows, err := s.db.Query(s.queries.queryByAge, age, limit)
+	if err != nil {
+		return err
+	}
+	_, err = drainRows(rows)
+	return err
+}
+
+func (s *sqlDriver) UpdateBalance(id int, balance float64) error {
+	_, err := s.db.Exec(s.queries.updateBalance, balance, id)
+	return err
+}
+
+func (s *sqlDriver) Aggregate() (int, error) {
+	rows, err := s.db.Query(s.queries.aggregate)
+	if err != nil {
+		return 0, err
+	}
+	return drainRows(rows)
+}
+
+func (s *sqlDriver) Transfer(fromID, toID int, amount float64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(s.queries.debit, amount, fromID); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			fmt.Printf("Warning: failed to rollback transaction: %v\n", txErr)
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(s.queries.credit, amount, toID); err != nil {
+		if txErr := tx.Rollback(); txErr != nil {
+			fmt.Printf("Warning: failed to rollback transaction: %v\n", txErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqlDriver) Execute(query string, args []sql.NamedArg) (int, error) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+		if v, ok := arg.Value.(bool); ok {
+			values[i] = s.boolArg(v)
+		}
+	}
+
+	rows, err := s.db.Query(query, values...)
+	if err != nil {
+		return 0, err
+	}
+	return drainRows(rows)
+}
+
+// drainRows reads and decodes every row so that engines which stream results
+// lazily are measured for the full fetch, then closes rows.
+func drainRows(rows *sql.Rows) (int, error) {
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("Warning: failed to close rows: %v\n", err)
+		}
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	count := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, rows.Err()
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"github.com/nadmax/dbcompare/internal/config"
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	Register(Registration{
+		Name: "sqlite",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Databases.SQLite.Enabled
+		},
+		Open: func(cfg *config.Config) (Driver, error) {
+			return NewSQLiteDB(&cfg.Databases.SQLite)
+		},
+	})
+}
+
+type SQLiteDB struct {
+	sqlDriver
+	config *config.SQLiteConfig
+}
+
+func NewSQLiteDB(cfg *config.SQLiteConfig) (*SQLiteDB, error) {
+	// Every connection to :memory: opens its own private database, so the
+	// pool is pinned to a single connection to keep one shared dataset.
+	maxConnections := cfg.MaxConnections
+	if cfg.Path == ":memory:" {
+		maxConnections = 1
+	}
+
+	db, err := openSQL("sqlite", cfg.DSN(), maxConnections)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLiteDB{
+		sqlDriver: sqlDriver{
+			db:      db,
+			name:    "SQLite",
+			queries: sqliteQueries,
+			tpcb:    &sqliteTPCBQueries,
+			tpcc:    newTPCCQueries("", nil),
+			tables: &tableDialect{
+				// INTEGER PRIMARY KEY aliases the rowid, which SQLite assigns.
+				types:        withTypes(standardTableTypes, map[string]string{"serial": "INTEGER"}),
+				numericBools: true,
+				dropTable:    dropTableIfExists,
+			},
+			documents: &sqliteDocumentQueries,
+		},
+		config: cfg,
+	}, nil
+}
+
+func (s *SQLiteDB) GetStats() (map[string]any, error) {
+	stats := make(map[string]any)
+
+	var count int64
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM benchmark_records").Scan(&count); err != nil {
+		return nil, err
+	}
+	stats["row_count"] = count
+
+	var journalMode, synchronous string
+	if err := s.db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+		return nil, err
+	}
+	if err := s.db.QueryRow("PRAGMA synchronous").Scan(&synchronous); err != nil {
+		return nil, err
+	}
+	stats["journal_mode"] = journalMode
+	stats["synchronous"] = synchronous
+	stats["path"] = s.config.Path
+
+	return stats, nil
+}
+
+var sqliteQueries = sqlQueries{
+	truncate: []string{
+		`DELETE FROM benchmark_records`,
+	},
+	insert: `
+		INSERT INTO benchmark_records (name, email, age, balance, created_at, description, is_active)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+	readByID:      `SELECT * FROM benchmark_records WHERE id = ?`,
+	scan:          `SELECT * FROM benchmark_records LIMIT ?`,
+	scanRange:     `SELECT * FROM benchmark_records WHERE id >= ? ORDER BY id LIMIT ?`,
+	queryByAge:    `SELECT * FROM benchmark_records WHERE age = ? LIMIT ?`,
+	updateBalance: `UPDATE benchmark_records SET balance = ? WHERE id = ?`,
+	aggregate: `
+		SELECT
+			age,
+			COUNT(*) as user_count,
+			AVG(balance) as avg_balance,
+			MAX(balance) as max_balance,
+			MIN(balance) as min_balance
+		FROM benchmark_records
+		WHERE is_active = 1 AND age > 25
+		GROUP BY age
+		HAVING COUNT(*) > 5
+		ORDER BY avg_balance DESC
+		LIMIT 50
+	`,
+	debit:  `UPDATE benchmark_records SET balance = balance - ? WHERE id = ?`,
+	credit: `UPDATE benchmark_records SET balance = balance + ? WHERE id = ?`,
+}
+
+var sqliteTPCBQueries = tpcbQueries{
+	schema: []string{
+		`DROP TABLE IF EXISTS tpcb_history`,
+		`DROP TABLE IF EXISTS tpcb_accounts`,
+		`DROP TABLE IF EXISTS tpcb_tellers`,
+		`DROP TABLE IF EXISTS tpcb_branches`,
+		`CREATE TABLE tpcb_branches (
+			bid INTEGER PRIMARY KEY,
+			bbalance INTEGER NOT NULL,
+			filler CHAR(88)
+		)`,
+		`CREATE TABLE tpcb_tellers (
+			tid INTEGER PRIMARY KEY,
+			bid INTEGER NOT NULL,
+			tbalance INTEGER NOT NULL,
+			filler CHAR(84)
+		)`,
+		`CREATE TABLE tpcb_accounts (
+			aid INTEGER PRIMARY KEY,
+			bid INTEGER NOT NULL,
+			abalance INTEGER NOT NULL,
+			filler CHAR(84)
+		)`,
+		`CREATE TABLE tpcb_history (
+			tid INTEGER NOT NULL,
+			bid INTEGER NOT NULL,
+			aid INTEGER NOT NULL,
+			delta INTEGER NOT NULL,
+			mtime TIMESTAMP NOT NULL,
+			filler CHAR(22)
+		)`,
+	},
+	populate: []string{
+		`INSERT INTO tpcb_branches (bid, bbalance)
+			WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < ?)
+			SELECT n, 0 FROM seq`,
+		`INSERT INTO tpcb_tellers (tid, bid, tbalance)
+			WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < ? * 10)
+			SELECT n, (n - 1) / 10 + 1, 0 FROM seq`,
+		`INSERT INTO tpcb_accounts (aid, bid, abalance, filler)
+			WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < ? * 100000)
+			SELECT n, (n - 1) / 100000 + 1, 0, '' FROM seq`,
+	},
+	updateAccount: `UPDATE tpcb_accounts SET abalance = abalance + ? WHERE aid = ?`,
+	selectAccount: `SELECT abalance FROM tpcb_accounts WHERE aid = ?`,
+	updateTeller:  `UPDATE tpcb_tellers SET tbalance = tbalance + ? WHERE tid = ?`,
+	updateBranch:  `UPDATE tpcb_branches SET bbalance = bbalance + ? WHERE bid = ?`,
+	insertHistory: `INSERT INTO tpcb_history (tid, bid, aid, delta, mtime) VALUES (?, ?, ?, ?, ?)`,
+	totals:        tpcbTotalsQuery,
+}
+
+// sqliteDocumentQueries stores documents as JSON text and filters them with
+// the JSON1 functions, with an expression index on the city path.
+var sqliteDocumentQueries = documentQueries{
+	schema: []string{
+		`DROP TABLE IF EXISTS documents`,
+		`CREATE TABLE documents (
+			id INTEGER PRIMARY KEY,
+			body TEXT NOT NULL
+		)`,
+		`CREATE INDEX idx_documents_city ON documents (json_extract(body, '$.profile.address.city'))`,
+	},
+	insert:      `INSERT INTO documents (id, body) VALUES (?, ?)`,
+	queryByCity: `SELECT id, body FROM documents WHERE json_extract(body, '$.profile.address.city') = ? LIMIT ?`,
+	queryByTag: `SELECT id, body FROM documents
+		WHERE EXISTS (SELECT 1 FROM json_each(documents.body, '$.profile.preferences.tags') WHERE value = ?)
+		LIMIT ?`,
+	updateTier:  `UPDATE documents SET body = json_set(body, '$.profile.preferences.tier', ?) WHERE id = ?`,
+	appendEvent: `UPDATE documents SET body = json_insert(body, '$.events[#]', json(?)) WHERE id = ?`,
+}
@@ -2,17 +2,60 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/nadmax/dbcompare/internal/config"
+	internalmodels "github.com/nadmax/dbcompare/internal/models"
 	"github.com/surrealdb/surrealdb.go"
 	"github.com/surrealdb/surrealdb.go/pkg/models"
 )
 
+func init() {
+	Register(Registration{
+		Name: "surrealdb",
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.Databases.SurrealDB.Enabled
+		},
+		Open: func(cfg *config.Config) (Driver, error) {
+			return NewSurrealDB(&cfg.Databases.SurrealDB)
+		},
+	})
+}
+
 type SurrealDB struct {
 	db     *surrealdb.DB
 	config *config.SurrealDBConfig
 	ctx    context.Context
+
+	// lastID numbers inserted records like the SQL engines' serial key, so the
+	// n-th record is test_records:<n> and point operations address it directly.
+	lastID atomic.Int64
+}
+
+type SurrealRecord struct {
+	ID          *models.RecordID      `json:"id,omitempty"`
+	Name        string                `json:"name"`
+	Email       string                `json:"email"`
+	Age         int                   `json:"age"`
+	Balance     float64               `json:"balance"`
+	CreatedAt   models.CustomDateTime `json:"created_at"`
+	Description string                `json:"description"`
+	IsActive    bool                  `json:"is_active"`
+}
+
+func newSurrealRecord(record internalmodels.TestRecord) SurrealRecord {
+	return SurrealRecord{
+		Name:        record.Name,
+		Email:       record.Email,
+		Age:         record.Age,
+		Balance:     record.Balance,
+		CreatedAt:   models.CustomDateTime{Time: record.CreatedAt},
+		Description: record.Description,
+		IsActive:    record.IsActive,
+	}
 }
 
 func NewSurrealDB(cfg *config.SurrealDBConfig) (*SurrealDB, error) {
@@ -42,6 +85,13 @@ func NewSurrealDB(cfg *config.SurrealDBConfig) (*SurrealDB, error) {
 	}, nil
 }
 
+func (s *SurrealDB) Name() string {
+	if s.config.SchemaMode == SurrealSchemafull {
+		return "SurrealDB (schemafull)"
+	}
+	return "SurrealDB"
+}
+
 func (s *SurrealDB) DB() *surrealdb.DB {
 	return s.db
 }
@@ -54,31 +104,370 @@ func (s *SurrealDB) Close() error {
 	return s.db.Close(s.ctx)
 }
 
+// CreateSchema recreates test_records from the shared records description,
+// schemaless or schemafull depending on the configured schema mode.
 func (s *SurrealDB) CreateSchema() error {
-	_, err := surrealdb.Delete[[]map[string]any](s.ctx, s.db, models.Table("test_records"))
-	if err != nil {
-		fmt.Printf("Note: Could not delete test_records (might not exist): %v\n", err)
-	}
+	s.lastID.Store(0)
+
+	table := recordsTable
+	table.Name = "test_records"
+	return s.defineTable(table)
+}
 
+func (s *SurrealDB) defineTable(table config.TableConfig) error {
+	for _, statement := range surrealSchema(table, s.config.SchemaMode) {
+		if _, err := surrealdb.Query[any](s.ctx, s.db, statement, nil); err != nil {
+			return fmt.Errorf("failed to define %s: %w", table.Name, err)
+		}
+	}
 	return nil
 }
 
 func (s *SurrealDB) TruncateTable() error {
 	_, err := surrealdb.Delete[[]map[string]any](s.ctx, s.db, models.Table("test_records"))
+	s.lastID.Store(0)
 	return err
 }
 
 func (s *SurrealDB) GetStats() (map[string]any, error) {
 	stats := make(map[string]any)
 
-	records, err := surrealdb.Select[[]map[string]any](s.ctx, s.db, models.Table("test_records"))
+	results, err := surrealdb.Query[[]map[string]any](s.ctx, s.db,
+		"SELECT count() AS row_count FROM test_records GROUP ALL", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	stats["row_count"] = len(*records)
+	stats["row_count"] = int64(0)
+	if len(*results) > 0 && len((*results)[0].Result) > 0 {
+		stats["row_count"] = surrealInt((*results)[0].Result[0]["row_count"])
+	}
 	stats["namespace"] = s.config.Namespace
 	stats["database"] = s.config.Database
 
 	return stats, nil
 }
+
+// Insert sends the whole batch in a single insert call, numbering the records
+// from a block of IDs reserved up front.
+func (s *SurrealDB) Insert(records []internalmodels.TestRecord) (int, error) {
+	first := int(s.lastID.Add(int64(len(records)))) - len(records) + 1
+	batch := make([]SurrealRecord, len(records))
+	for i, record := range records {
+		id := recordID(first + i)
+		batch[i] = newSurrealRecord(record)
+		batch[i].ID = &id
+	}
+
+	if _, err := surrealdb.Insert[SurrealRecord](s.ctx, s.db, models.Table("test_records"), batch); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (s *SurrealDB) ReadByID(id int) error {
+	_, err := surrealdb.Select[SurrealRecord](s.ctx, s.db, recordID(id))
+	return err
+}
+
+func (s *SurrealDB) Scan(limit int) (int, error) {
+	results, err := surrealdb.Query[[]SurrealRecord](s.ctx, s.db,
+		"SELECT * FROM test_records LIMIT $limit",
+		map[string]any{"limit": limit})
+	if err != nil {
+		return 0, err
+	}
+	if len(*results) == 0 {
+		return 0, nil
+	}
+
+	return len((*results)[0].Result), nil
+}
+
+// ScanRange selects a record ID range, which SurrealDB reads in key order
+// like the SQL engines' primary key range.
+func (s *SurrealDB) ScanRange(startID, count int) (int, error) {
+	if count < 1 {
+		return 0, nil
+	}
+
+	results, err := surrealdb.Query[[]SurrealRecord](s.ctx, s.db,
+		fmt.Sprintf("SELECT * FROM test_records:%d..=%d", startID, startID+count-1), nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(*results) == 0 {
+		return 0, nil
+	}
+
+	return len((*results)[0].Result), nil
+}
+
+func (s *SurrealDB) QueryByAge(age, limit int) error {
+	_, err := surrealdb.Query[[]SurrealRecord](s.ctx, s.db,
+		"SELECT * FROM test_records WHERE age = $age LIMIT $limit",
+		map[string]any{"age": age, "limit": limit})
+	return err
+}
+
+func (s *SurrealDB) UpdateBalance(id int, balance float64) error {
+	_, err := surrealdb.Merge[SurrealRecord](s.ctx, s.db, recordID(id), map[string]any{
+		"balance": balance,
+	})
+	return err
+}
+
+// Aggregate mirrors the SQL aggregation. SurrealQL has no HAVING, so the
+// grouped rows are filtered by an outer SELECT.
+func (s *SurrealDB) Aggregate() (int, error) {
+	results, err := surrealdb.Query[[]map[string]any](s.ctx, s.db, `
+		SELECT * FROM (
+			SELECT
+				age,
+				count() AS user_count,
+				math::mean(balance) AS avg_balance,
+				math::max(balance) AS max_balance,
+				math::min(balance) AS min_balance
+			FROM test_records
+			WHERE is_active = true AND age > 25
+			GROUP BY age
+		)
+		WHERE user_count > 5
+		ORDER BY avg_balance DESC
+		LIMIT 50`, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(*results) == 0 {
+		return 0, nil
+	}
+
+	return len((*results)[0].Result), nil
+}
+
+func (s *SurrealDB) Transfer(fromID, toID int, amount float64) error {
+	_, err := surrealdb.Query[any](s.ctx, s.db, `
+		BEGIN TRANSACTION;
+		UPDATE $from SET balance -= $amount;
+		UPDATE $to SET balance += $amount;
+		COMMIT TRANSACTION;`,
+		map[string]any{"from": recordID(fromID), "to": recordID(toID), "amount": amount})
+	return err
+}
+
+func (s *SurrealDB) Execute(query string, args []sql.NamedArg) (int, error) {
+	vars := make(map[string]any, len(args))
+	for _, arg := range args {
+		vars[arg.Name] = arg.Value
+	}
+
+	results, err := surrealdb.Query[any](s.ctx, s.db, query, vars)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	for _, result := range *results {
+		if list, ok := result.Result.([]any); ok {
+			rows += len(list)
+		}
+	}
+	return rows, nil
+}
+
+// recordID returns the ID of the n-th inserted record.
+func recordID(n int) models.RecordID {
+	return models.NewRecordID("test_records", n)
+}
+
+// CreateTables recreates the custom tables in the configured schema mode.
+// Foreign keys are stored as plain integers, as in the SQL engines, rather
+// than as record links.
+func (s *SurrealDB) CreateTables(tables []config.TableConfig) error {
+	for _, table := range tables {
+		if err := s.defineTable(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertRows inserts a batch in one INSERT statement, keyed by the primary
+// key so that records get the ID table:<key>.
+func (s *SurrealDB) InsertRows(table config.TableConfig, rows [][]any) (int, error) {
+	documents := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		document := make(map[string]any, len(row))
+		for j, column := range table.Columns {
+			name := column.Name
+			if column.PrimaryKey {
+				name = "id"
+			}
+			if t, ok := row[j].(time.Time); ok {
+				document[name] = models.CustomDateTime{Time: t}
+			} else {
+				document[name] = row[j]
+			}
+		}
+		documents[i] = document
+	}
+
+	_, err := surrealdb.Query[any](s.ctx, s.db, "INSERT INTO "+table.Name+" $rows", map[string]any{"rows": documents})
+	return 0, err
+}
+
+// CreateDocumentSchema recreates the documents table with indexes on the
+// nested city and tier fields.
+func (s *SurrealDB) CreateDocumentSchema() error {
+	for _, query := range []string{
+		"REMOVE TABLE IF EXISTS documents",
+		"DEFINE TABLE documents SCHEMALESS",
+		"DEFINE INDEX idx_documents_city ON TABLE documents FIELDS profile.address.city",
+		"DEFINE INDEX idx_documents_tier ON TABLE documents FIELDS profile.preferences.tier",
+	} {
+		if _, err := surrealdb.Query[any](s.ctx, s.db, query, nil); err != nil {
+			return fmt.Errorf("failed to create documents schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// InsertDocuments inserts a batch in one INSERT statement; each document's ID
+// becomes its record ID documents:<id>.
+func (s *SurrealDB) InsertDocuments(docs []internalmodels.Document) (int, error) {
+	_, err := surrealdb.Query[any](s.ctx, s.db, "INSERT INTO documents $docs", map[string]any{"docs": docs})
+	return 0, err
+}
+
+func (s *SurrealDB) QueryDocumentsByCity(city string, limit int) (int, error) {
+	return s.queryDocuments("SELECT * FROM documents WHERE profile.address.city = $value LIMIT $limit", city, limit)
+}
+
+func (s *SurrealDB) QueryDocumentsByTag(tag string, limit int) (int, error) {
+	return s.queryDocuments("SELECT * FROM documents WHERE profile.preferences.tags CONTAINS $value LIMIT $limit", tag, limit)
+}
+
+func (s *SurrealDB) queryDocuments(query, value string, limit int) (int, error) {
+	results, err := surrealdb.Query[[]map[string]any](s.ctx, s.db, query,
+		map[string]any{"value": value, "limit": limit})
+	if err != nil {
+		return 0, err
+	}
+	if len(*results) == 0 {
+		return 0, nil
+	}
+
+	return len((*results)[0].Result), nil
+}
+
+func (s *SurrealDB) UpdateDocumentTier(id int, tier string) error {
+	_, err := surrealdb.Query[any](s.ctx, s.db,
+		"UPDATE type::thing('documents', $id) SET profile.preferences.tier = $tier",
+		map[string]any{"id": id, "tier": tier})
+	return err
+}
+
+func (s *SurrealDB) AppendDocumentEvent(id int, event internalmodels.DocumentEvent) error {
+	_, err := surrealdb.Query[any](s.ctx, s.db,
+		"UPDATE type::thing('documents', $id) SET events += $event",
+		map[string]any{"id": id, "event": event})
+	return err
+}
+
+// tpcbInsertBatch bounds the rows sent per INSERT while populating TPC-B.
+const tpcbInsertBatch = 10000
+
+func (s *SurrealDB) CreateTPCBSchema(scale int) error {
+	for _, table := range []string{"tpcb_history", "tpcb_accounts", "tpcb_tellers", "tpcb_branches"} {
+		if _, err := surrealdb.Query[any](s.ctx, s.db, "REMOVE TABLE IF EXISTS "+table, nil); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", table, err)
+		}
+	}
+
+	tables := []struct {
+		name  string
+		count int
+		row   func(n int) map[string]any
+	}{
+		{"tpcb_branches", scale, func(n int) map[string]any {
+			return map[string]any{"id": n, "bbalance": 0}
+		}},
+		{"tpcb_tellers", scale * TPCBTellersPerBranch, func(n int) map[string]any {
+			return map[string]any{"id": n, "bid": (n-1)/TPCBTellersPerBranch + 1, "tbalance": 0}
+		}},
+		{"tpcb_accounts", scale * TPCBAccountsPerBranch, func(n int) map[string]any {
+			return map[string]any{"id": n, "bid": (n-1)/TPCBAccountsPerBranch + 1, "abalance": 0, "filler": ""}
+		}},
+	}
+
+	for _, table := range tables {
+		for start := 1; start <= table.count; start += tpcbInsertBatch {
+			end := min(start+tpcbInsertBatch-1, table.count)
+			rows := make([]map[string]any, 0, end-start+1)
+			for n := start; n <= end; n++ {
+				rows = append(rows, table.row(n))
+			}
+			if _, err := surrealdb.Query[any](s.ctx, s.db, "INSERT INTO "+table.name+" $rows", map[string]any{"rows": rows}); err != nil {
+				return fmt.Errorf("failed to populate %s: %w", table.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *SurrealDB) TPCBTransaction(aid, tid, bid, delta int) error {
+	_, err := surrealdb.Query[any](s.ctx, s.db, `
+		BEGIN TRANSACTION;
+		UPDATE type::thing('tpcb_accounts', $aid) SET abalance += $delta;
+		SELECT abalance FROM type::thing('tpcb_accounts', $aid);
+		UPDATE type::thing('tpcb_tellers', $tid) SET tbalance += $delta;
+		UPDATE type::thing('tpcb_branches', $bid) SET bbalance += $delta;
+		CREATE tpcb_history SET tid = $tid, bid = $bid, aid = $aid, delta = $delta, mtime = time::now();
+		COMMIT TRANSACTION;`,
+		map[string]any{"aid": aid, "tid": tid, "bid": bid, "delta": delta})
+	return err
+}
+
+func (s *SurrealDB) TPCBTotals() (TPCBTotals, error) {
+	var totals TPCBTotals
+
+	results, err := surrealdb.Query[[]any](s.ctx, s.db, `RETURN [
+		math::sum((SELECT VALUE abalance FROM tpcb_accounts)),
+		math::sum((SELECT VALUE tbalance FROM tpcb_tellers)),
+		math::sum((SELECT VALUE bbalance FROM tpcb_branches)),
+		math::sum((SELECT VALUE delta FROM tpcb_history)),
+		count((SELECT VALUE id FROM tpcb_history))
+	]`, nil)
+	if err != nil {
+		return totals, err
+	}
+	if len(*results) == 0 || len((*results)[0].Result) != 5 {
+		return totals, fmt.Errorf("unexpected tpcb totals result")
+	}
+
+	sums := (*results)[0].Result
+	totals.Accounts = surrealInt(sums[0])
+	totals.Tellers = surrealInt(sums[1])
+	totals.Branches = surrealInt(sums[2])
+	totals.History = surrealInt(sums[3])
+	totals.HistoryRows = surrealInt(sums[4])
+	return totals, nil
+}
+
+// surrealInt converts a number decoded from CBOR, whose Go type depends on its
+// sign and encoding.
+func surrealInt(v any) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case uint64:
+		return int64(n)
+	case float64:
+		return int64(n)
+	case int:
+		return int64(n)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nadmax/dbcompare/internal/config"
+)
+
+// TableDriver is implemented by engines that can create and load the custom
+// tables described under benchmark.tables.
+type TableDriver interface {
+	// CreateTables drops and recreates the given tables, which come ordered
+	// so that referenced tables precede the tables referencing them.
+	CreateTables(tables []config.TableConfig) error
+	// InsertRows inserts rows whose values follow the table's column order
+	// and returns how many of them failed.
+	InsertRows(table config.TableConfig, rows [][]any) (int, error)
+}
+
+// tableDialect maps the column types of custom tables to an engine's DDL. The
+// string type is a format taking the column's maximum length. Engines with
+// numericBools spell boolean defaults as 1 and 0.
+type tableDialect struct {
+	types        map[string]string
+	numericBools bool
+	tableOptions string
+	dropTable    func(table string) string
+	rebind       func(query string) string
+}
+
+var standardTableTypes = map[string]string{
+	"int":       "BIGINT",
+	"int32":     "INTEGER",
+	"float":     "DOUBLE PRECISION",
+	"decimal":   "DECIMAL(10,2)",
+	"string":    "VARCHAR(%d)",
+	"text":      "TEXT",
+	"bool":      "BOOLEAN",
+	"timestamp": "TIMESTAMP",
+}
+
+func dropTableIfExists(table string) string {
+	return "DROP TABLE IF EXISTS " + table
+}
+
+func (d *tableDialect) createTable(table config.TableConfig) []string {
+	definitions := make([]string, 0, len(table.Columns))
+	var foreignKeys, indexes []string
+	for _, column := range table.Columns {
+		definition := column.Name + " " + d.columnType(column)
+		if column.Default != nil {
+			definition += " DEFAULT " + d.literal(column.Default)
+		}
+		switch {
+		case column.PrimaryKey:
+			definition += " PRIMARY KEY"
+		case column.Nullable == 0:
+			definition += " NOT NULL"
+		}
+		definitions = append(definitions, definition)
+
+		if column.References != "" {
+			table, key, _ := strings.Cut(column.References, ".")
+			foreignKeys = append(foreignKeys, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", column.Name, table, key))
+		}
+		if column.Index {
+			indexes = append(indexes, fmt.Sprintf("CREATE INDEX %s ON %s (%s)", table.IndexName(column), table.Name, column.Name))
+		}
+	}
+
+	create := fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)%s", table.Name,
+		strings.Join(append(definitions, foreignKeys...), ",\n\t"), d.tableOptions)
+	return append([]string{create}, indexes...)
+}
+
+func (d *tableDialect) columnType(column config.ColumnConfig) string {
+	if column.Type == "string" {
+		return fmt.Sprintf(d.types["string"], column.MaxLength)
+	}
+	return d.types[column.Type]
+}
+
+func (d *tableDialect) literal(value any) string {
+	if v, ok := value.(bool); ok && d.numericBools {
+		if v {
+			return "1"
+		}
+		return "0"
+	}
+	return fmt.Sprint(value)
+}
+
+func (d *tableDialect) insert(table config.TableConfig) string {
+	names := make([]string, len(table.Columns))
+	placeholders := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		names[i] = column.Name
+		placeholders[i] = "?"
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Name, strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	if d.rebind != nil {
+		query = d.rebind(query)
+	}
+	return query
+}
+
+func (s *sqlDriver) CreateTables(tables []config.TableConfig) error {
+	if s.tables == nil {
+		return ErrUnsupported
+	}
+
+	queries := make([]string, 0, 3*len(tables))
+	for i := len(tables) - 1; i >= 0; i-- {
+		queries = append(queries, s.tables.dropTable(tables[i].Name))
+	}
+	for _, table := range tables {
+		queries = append(queries, s.tables.createTable(table)...)
+	}
+	return s.execAll(queries)
+}
+
+func (s *sqlDriver) InsertRows(table config.TableConfig, rows [][]any) (int, error) {
+	if s.tables == nil {
+		return 0, ErrUnsupported
+	}
+
+	failed := 0
+	err := s.inTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(s.tables.insert(table))
+		if err != nil {
+			return err
+		}
+		defer closeStmt(stmt)
+
+		for _, row := range rows {
+			args := make([]any, len(row))
+			for i, value := range row {
+				if v, ok := value.(bool); ok {
+					args[i] = s.boolArg(v)
+				} else {
+					args[i] = value
+				}
+			}
+			if _, err := stmt.Exec(args...); err != nil {
+				failed++
+			}
+		}
+		return nil
+	})
+	return failed, err
+}
+
+// colonPlaceholders rewrites ? placeholders as :1, :2, ...
+func colonPlaceholders(query string) string {
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			sb.WriteString(":" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Table sizes per branch (scale factor 1), as in TPC-B and pgbench.
+const (
+	TPCBTellersPerBranch  = 10
+	TPCBAccountsPerBranch = 100000
+)
+
+// TPCBDriver is implemented by engines that can run the TPC-B suite. Engines
+// without TPC-B support return ErrUnsupported.
+type TPCBDriver interface {
+	// CreateTPCBSchema recreates and populates branches, tellers, accounts
+	// and history for scale branches, with every balance at zero.
+	CreateTPCBSchema(scale int) error
+	// TPCBTransaction runs one TPC-B transaction: credit delta to the
+	// account, read it back, credit the teller and the branch and append a
+	// history row, all atomically.
+	TPCBTransaction(aid, tid, bid, delta int) error
+	TPCBTotals() (TPCBTotals, error)
+}
+
+// TPCBTotals sums every balance. Since all balances start at zero and each
+// transaction adds the same delta everywhere, the four sums stay equal.
+type TPCBTotals struct {
+	Accounts    int64 `json:"accounts"`
+	Tellers     int64 `json:"tellers"`
+	Branches    int64 `json:"branches"`
+	History     int64 `json:"history"`
+	HistoryRows int64 `json:"history_rows"`
+}
+
+func (t TPCBTotals) Conserved() bool {
+	return t.Accounts == t.Tellers && t.Tellers == t.Branches && t.Branches == t.History
+}
+
+// tpcbQueries holds the dialect-specific TPC-B statements of a database/sql
+// engine. populate statements take the scale factor as their only argument.
+type tpcbQueries struct {
+	schema        []string
+	populate      []string
+	updateAccount string
+	selectAccount string
+	updateTeller  string
+	updateBranch  string
+	insertHistory string
+	totals        string
+}
+
+func (s *sqlDriver) CreateTPCBSchema(scale int) error {
+	if s.tpcb == nil {
+		return ErrUnsupported
+	}
+
+	if err := s.execAll(s.tpcb.schema); err != nil {
+		return err
+	}
+	for _, query := range s.tpcb.populate {
+		if _, err := s.db.Exec(query, scale); err != nil {
+			return fmt.Errorf("failed to populate tpcb tables: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *sqlDriver) TPCBTransaction(aid, tid, bid, delta int) error {
+	if s.tpcb == nil {
+		return ErrUnsupported
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			fmt.Printf("Warning: failed to rollback transaction: %v\n", err)
+		}
+	}()
+
+	if _, err := tx.Exec(s.tpcb.updateAccount, delta, aid); err != nil {
+		return err
+	}
+	var balance int64
+	if err := tx.QueryRow(s.tpcb.selectAccount, aid).Scan(&balance); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(s.tpcb.updateTeller, delta, tid); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(s.tpcb.updateBranch, delta, bid); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(s.tpcb.insertHistory, tid, bid, aid, delta, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqlDriver) TPCBTotals() (TPCBTotals, error) {
+	var totals TPCBTotals
+	if s.tpcb == nil {
+		return totals, ErrUnsupported
+	}
+
+	err := s.db.QueryRow(s.tpcb.totals).Scan(
+		&totals.Accounts,
+		&totals.Tellers,
+		&totals.Branches,
+		&totals.History,
+		&totals.HistoryRows,
+	)
+	return totals, err
+}
+
+const tpcbTotalsQuery = `SELECT
+	(SELECT COALESCE(SUM(abalance), 0) FROM tpcb_accounts),
+	(SELECT COALESCE(SUM(tbalance), 0) FROM tpcb_tellers),
+	(SELECT COALESCE(SUM(bbalance), 0) FROM tpcb_branches),
+	(SELECT COALESCE(SUM(delta), 0) FROM tpcb_history),
+	(SELECT COUNT(*) FROM tpcb_history)`
@@ -0,0 +1,623 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Table sizes of the scaled-down TPC-C dataset. The specification's 100000
+// items and 3000 customers and orders per district are cut down so a
+// warehouse loads in seconds; the ratios between tables are kept.
+const (
+	TPCCItems                 = 10000
+	TPCCDistrictsPerWarehouse = 10
+	TPCCCustomersPerDistrict  = 300
+	TPCCOrdersPerDistrict     = 300
+	// TPCCNewOrdersPerDistrict is the number of initial orders, the most
+	// recent ones, left undelivered.
+	TPCCNewOrdersPerDistrict = 90
+)
+
+// TPCCDriver is implemented by engines that can run the TPC-C suite. Engines
+// without TPC-C support return ErrUnsupported.
+type TPCCDriver interface {
+	// CreateTPCCSchema recreates and populates every TPC-C table for the
+	// given number of warehouses, drawing the initial data from seed.
+	CreateTPCCSchema(warehouses int, seed int64) error
+	TPCCNewOrder(order TPCCNewOrder) error
+	TPCCPayment(payment TPCCPayment) error
+	TPCCOrderStatus(warehouseID, districtID, customerID int) error
+	TPCCDelivery(warehouseID, carrierID int) error
+	TPCCStockLevel(warehouseID, districtID, threshold int) error
+}
+
+type TPCCNewOrder struct {
+	WarehouseID int
+	DistrictID  int
+	CustomerID  int
+	Lines       []TPCCOrderLine
+}
+
+type TPCCOrderLine struct {
+	ItemID            int
+	SupplyWarehouseID int
+	Quantity          int
+}
+
+type TPCCPayment struct {
+	WarehouseID int
+	DistrictID  int
+	CustomerID  int
+	Amount      float64
+}
+
+// tpccQueries holds the TPC-C statements of a database/sql engine. They are
+// written once with ? placeholders and rebound to the engine's syntax.
+type tpccQueries struct {
+	schema []string
+
+	insertWarehouse string
+	insertDistrict  string
+	insertItem      string
+	insertStock     string
+	insertCustomer  string
+	insertOrder     string
+	insertNewOrder  string
+	insertOrderLine string
+	insertHistory   string
+
+	selectWarehouseTax  string
+	nextOrderID         string
+	selectDistrict      string
+	selectCustomer      string
+	selectItemPrice     string
+	updateStock         string
+	payWarehouse        string
+	payDistrict         string
+	payCustomer         string
+	customerBalance     string
+	lastOrder           string
+	orderLines          string
+	oldestNewOrder      string
+	deleteNewOrder      string
+	orderCustomer       string
+	setCarrier          string
+	deliverLines        string
+	orderTotal          string
+	creditCustomer      string
+	districtNextOrderID string
+	lowStock            string
+}
+
+// newTPCCQueries builds the TPC-C statements. tableOptions is appended to
+// every CREATE TABLE and rebind converts ? placeholders when the engine uses
+// another syntax.
+func newTPCCQueries(tableOptions string, rebind func(string) string) *tpccQueries {
+	if rebind == nil {
+		rebind = func(query string) string { return query }
+	}
+
+	tables := []string{
+		`tpcc_warehouse (
+			w_id INTEGER PRIMARY KEY,
+			w_name VARCHAR(10) NOT NULL,
+			w_tax DECIMAL(4,4) NOT NULL,
+			w_ytd DECIMAL(12,2) NOT NULL
+		)`,
+		`tpcc_district (
+			d_w_id INTEGER NOT NULL,
+			d_id INTEGER NOT NULL,
+			d_name VARCHAR(10) NOT NULL,
+			d_tax DECIMAL(4,4) NOT NULL,
+			d_ytd DECIMAL(12,2) NOT NULL,
+			d_next_o_id INTEGER NOT NULL,
+			PRIMARY KEY (d_w_id, d_id)
+		)`,
+		`tpcc_item (
+			i_id INTEGER PRIMARY KEY,
+			i_name VARCHAR(24) NOT NULL,
+			i_price DECIMAL(5,2) NOT NULL
+		)`,
+		`tpcc_stock (
+			s_w_id INTEGER NOT NULL,
+			s_i_id INTEGER NOT NULL,
+			s_quantity INTEGER NOT NULL,
+			s_ytd INTEGER NOT NULL,
+			s_order_cnt INTEGER NOT NULL,
+			s_remote_cnt INTEGER NOT NULL,
+			PRIMARY KEY (s_w_id, s_i_id)
+		)`,
+		`tpcc_customer (
+			c_w_id INTEGER NOT NULL,
+			c_d_id INTEGER NOT NULL,
+			c_id INTEGER NOT NULL,
+			c_last VARCHAR(16) NOT NULL,
+			c_credit CHAR(2) NOT NULL,
+			c_discount DECIMAL(4,4) NOT NULL,
+			c_balance DECIMAL(12,2) NOT NULL,
+			c_ytd_payment DECIMAL(12,2) NOT NULL,
+			c_payment_cnt INTEGER NOT NULL,
+			c_delivery_cnt INTEGER NOT NULL,
+			PRIMARY KEY (c_w_id, c_d_id, c_id)
+		)`,
+		`tpcc_history (
+			h_c_id INTEGER NOT NULL,
+			h_c_d_id INTEGER NOT NULL,
+			h_c_w_id INTEGER NOT NULL,
+			h_d_id INTEGER NOT NULL,
+			h_w_id INTEGER NOT NULL,
+			h_date TIMESTAMP NOT NULL,
+			h_amount DECIMAL(6,2) NOT NULL
+		)`,
+		`tpcc_orders (
+			o_w_id INTEGER NOT NULL,
+			o_d_id INTEGER NOT NULL,
+			o_id INTEGER NOT NULL,
+			o_c_id INTEGER NOT NULL,
+			o_entry_d TIMESTAMP NOT NULL,
+			o_carrier_id INTEGER,
+			o_ol_cnt INTEGER NOT NULL,
+			o_all_local INTEGER NOT NULL,
+			PRIMARY KEY (o_w_id, o_d_id, o_id)
+		)`,
+		`tpcc_new_order (
+			no_w_id INTEGER NOT NULL,
+			no_d_id INTEGER NOT NULL,
+			no_o_id INTEGER NOT NULL,
+			PRIMARY KEY (no_w_id, no_d_id, no_o_id)
+		)`,
+		`tpcc_order_line (
+			ol_w_id INTEGER NOT NULL,
+			ol_d_id INTEGER NOT NULL,
+			ol_o_id INTEGER NOT NULL,
+			ol_number INTEGER NOT NULL,
+			ol_i_id INTEGER NOT NULL,
+			ol_supply_w_id INTEGER NOT NULL,
+			ol_delivery_d TIMESTAMP,
+			ol_quantity INTEGER NOT NULL,
+			ol_amount DECIMAL(6,2) NOT NULL,
+			PRIMARY KEY (ol_w_id, ol_d_id, ol_o_id, ol_number)
+		)`,
+	}
+
+	schema := make([]string, 0, 2*len(tables)+1)
+	for i := len(tables) - 1; i >= 0; i-- {
+		name, _, _ := strings.Cut(tables[i], " ")
+		schema = append(schema, "DROP TABLE IF EXISTS "+name)
+	}
+	for _, table := range tables {
+		schema = append(schema, "CREATE TABLE "+table+tableOptions)
+	}
+	schema = append(schema, `CREATE INDEX idx_tpcc_orders_customer ON tpcc_orders (o_w_id, o_d_id, o_c_id, o_id)`)
+
+	return &tpccQueries{
+		schema: schema,
+
+		insertWarehouse: rebind(`INSERT INTO tpcc_warehouse (w_id, w_name, w_tax, w_ytd) VALUES (?, ?, ?, ?)`),
+		insertDistrict:  rebind(`INSERT INTO tpcc_district (d_w_id, d_id, d_name, d_tax, d_ytd, d_next_o_id) VALUES (?, ?, ?, ?, ?, ?)`),
+		insertItem:      rebind(`INSERT INTO tpcc_item (i_id, i_name, i_price) VALUES (?, ?, ?)`),
+		insertStock:     rebind(`INSERT INTO tpcc_stock (s_w_id, s_i_id, s_quantity, s_ytd, s_order_cnt, s_remote_cnt) VALUES (?, ?, ?, 0, 0, 0)`),
+		insertCustomer: rebind(`INSERT INTO tpcc_customer (c_w_id, c_d_id, c_id, c_last, c_credit, c_discount, c_balance, c_ytd_payment, c_payment_cnt, c_delivery_cnt)
+			VALUES (?, ?, ?, ?, ?, ?, -10, 10, 1, 0)`),
+		insertOrder: rebind(`INSERT INTO tpcc_orders (o_w_id, o_d_id, o_id, o_c_id, o_entry_d, o_carrier_id, o_ol_cnt, o_all_local)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		insertNewOrder: rebind(`INSERT INTO tpcc_new_order (no_w_id, no_d_id, no_o_id) VALUES (?, ?, ?)`),
+		insertOrderLine: rebind(`INSERT INTO tpcc_order_line (ol_w_id, ol_d_id, ol_o_id, ol_number, ol_i_id, ol_supply_w_id, ol_delivery_d, ol_quantity, ol_amount)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		insertHistory: rebind(`INSERT INTO tpcc_history (h_c_id, h_c_d_id, h_c_w_id, h_d_id, h_w_id, h_date, h_amount)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`),
+
+		selectWarehouseTax: rebind(`SELECT w_tax FROM tpcc_warehouse WHERE w_id = ?`),
+		nextOrderID:        rebind(`UPDATE tpcc_district SET d_next_o_id = d_next_o_id + 1 WHERE d_w_id = ? AND d_id = ?`),
+		selectDistrict:     rebind(`SELECT d_next_o_id - 1, d_tax FROM tpcc_district WHERE d_w_id = ? AND d_id = ?`),
+		selectCustomer:     rebind(`SELECT c_discount, c_last, c_credit FROM tpcc_customer WHERE c_w_id = ? AND c_d_id = ? AND c_id = ?`),
+		selectItemPrice:    rebind(`SELECT i_price FROM tpcc_item WHERE i_id = ?`),
+		updateStock: rebind(`UPDATE tpcc_stock SET
+			s_quantity = CASE WHEN s_quantity - ? >= 10 THEN s_quantity - ? ELSE s_quantity - ? + 91 END,
+			s_ytd = s_ytd + ?,
+			s_order_cnt = s_order_cnt + 1,
+			s_remote_cnt = s_remote_cnt + ?
+			WHERE s_w_id = ? AND s_i_id = ?`),
+		payWarehouse: rebind(`UPDATE tpcc_warehouse SET w_ytd = w_ytd + ? WHERE w_id = ?`),
+		payDistrict:  rebind(`UPDATE tpcc_district SET d_ytd = d_ytd + ? WHERE d_w_id = ? AND d_id = ?`),
+		payCustomer: rebind(`UPDATE tpcc_customer SET c_balance = c_balance - ?, c_ytd_payment = c_ytd_payment + ?, c_payment_cnt = c_payment_cnt + 1
+			WHERE c_w_id = ? AND c_d_id = ? AND c_id = ?`),
+		customerBalance: rebind(`SELECT c_balance, c_last FROM tpcc_customer WHERE c_w_id = ? AND c_d_id = ? AND c_id = ?`),
+		lastOrder: rebind(`SELECT o_id, o_entry_d, o_carrier_id FROM tpcc_orders
+			WHERE o_w_id = ? AND o_d_id = ? AND o_c_id = ? ORDER BY o_id DESC LIMIT 1`),
+		orderLines: rebind(`SELECT ol_i_id, ol_supply_w_id, ol_quantity, ol_amount, ol_delivery_d FROM tpcc_order_line
+			WHERE ol_w_id = ? AND ol_d_id = ? AND ol_o_id = ?`),
+		oldestNewOrder: rebind(`SELECT MIN(no_o_id) FROM tpcc_new_order WHERE no_w_id = ? AND no_d_id = ?`),
+		deleteNewOrder: rebind(`DELETE FROM tpcc_new_order WHERE no_w_id = ? AND no_d_id = ? AND no_o_id = ?`),
+		orderCustomer:  rebind(`SELECT o_c_id FROM tpcc_orders WHERE o_w_id = ? AND o_d_id = ? AND o_id = ?`),
+		setCarrier:     rebind(`UPDATE tpcc_orders SET o_carrier_id = ? WHERE o_w_id = ? AND o_d_id = ? AND o_id = ?`),
+		deliverLines:   rebind(`UPDATE tpcc_order_line SET ol_delivery_d = ? WHERE ol_w_id = ? AND ol_d_id = ? AND ol_o_id = ?`),
+		orderTotal:     rebind(`SELECT COALESCE(SUM(ol_amount), 0) FROM tpcc_order_line WHERE ol_w_id = ? AND ol_d_id = ? AND ol_o_id = ?`),
+		creditCustomer: rebind(`UPDATE tpcc_customer SET c_balance = c_balance + ?, c_delivery_cnt = c_delivery_cnt + 1
+			WHERE c_w_id = ? AND c_d_id = ? AND c_id = ?`),
+		districtNextOrderID: rebind(`SELECT d_next_o_id FROM tpcc_district WHERE d_w_id = ? AND d_id = ?`),
+		lowStock: rebind(`SELECT COUNT(DISTINCT s_i_id) FROM tpcc_order_line
+			JOIN tpcc_stock ON s_w_id = ol_w_id AND s_i_id = ol_i_id
+			WHERE ol_w_id = ? AND ol_d_id = ? AND ol_o_id >= ? AND ol_o_id < ? AND s_quantity < ?`),
+	}
+}
+
+// dollarPlaceholders rewrites ? placeholders as $1, $2, ...
+func dollarPlaceholders(query string) string {
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func (s *sqlDriver) CreateTPCCSchema(warehouses int, seed int64) error {
+	if s.tpcc == nil {
+		return ErrUnsupported
+	}
+
+	if err := s.execAll(s.tpcc.schema); err != nil {
+		return err
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	if err := s.inTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(s.tpcc.insertItem)
+		if err != nil {
+			return err
+		}
+		defer closeStmt(stmt)
+
+		for i := 1; i <= TPCCItems; i++ {
+			price := float64(100+rng.Intn(9901)) / 100
+			if _, err := stmt.Exec(i, fmt.Sprintf("item-%d", i), price); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to load tpcc items: %w", err)
+	}
+
+	for w := 1; w <= warehouses; w++ {
+		if err := s.inTx(func(tx *sql.Tx) error { return s.loadTPCCWarehouse(tx, rng, w) }); err != nil {
+			return fmt.Errorf("failed to load tpcc warehouse %d: %w", w, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *sqlDriver) loadTPCCWarehouse(tx *sql.Tx, rng *rand.Rand, w int) error {
+	tax := func() float64 { return float64(rng.Intn(2001)) / 10000 }
+	now := time.Now()
+
+	if _, err := tx.Exec(s.tpcc.insertWarehouse, w, fmt.Sprintf("wh-%d", w), tax(), 300000); err != nil {
+		return err
+	}
+
+	stock, err := tx.Prepare(s.tpcc.insertStock)
+	if err != nil {
+		return err
+	}
+	defer closeStmt(stock)
+	for i := 1; i <= TPCCItems; i++ {
+		if _, err := stock.Exec(w, i, 10+rng.Intn(91)); err != nil {
+			return err
+		}
+	}
+
+	customer, err := tx.Prepare(s.tpcc.insertCustomer)
+	if err != nil {
+		return err
+	}
+	defer closeStmt(customer)
+	order, err := tx.Prepare(s.tpcc.insertOrder)
+	if err != nil {
+		return err
+	}
+	defer closeStmt(order)
+	newOrder, err := tx.Prepare(s.tpcc.insertNewOrder)
+	if err != nil {
+		return err
+	}
+	defer closeStmt(newOrder)
+	line, err := tx.Prepare(s.tpcc.insertOrderLine)
+	if err != nil {
+		return err
+	}
+	defer closeStmt(line)
+
+	for d := 1; d <= TPCCDistrictsPerWarehouse; d++ {
+		if _, err := tx.Exec(s.tpcc.insertDistrict, w, d, fmt.Sprintf("dist-%d", d), tax(), 30000, TPCCOrdersPerDistrict+1); err != nil {
+			return err
+		}
+
+		for c := 1; c <= TPCCCustomersPerDistrict; c++ {
+			credit := "GC"
+			if rng.Intn(10) == 0 {
+				credit = "BC"
+			}
+			last := fmt.Sprintf("cust-%d", c)
+			if _, err := customer.Exec(w, d, c, last, credit, float64(rng.Intn(5001))/10000); err != nil {
+				return err
+			}
+		}
+
+		// Initial orders go to customers in a random permutation, as in the
+		// specification; the most recent ones are still awaiting delivery.
+		customers := rng.Perm(TPCCCustomersPerDistrict)
+		for o := 1; o <= TPCCOrdersPerDistrict; o++ {
+			delivered := o <= TPCCOrdersPerDistrict-TPCCNewOrdersPerDistrict
+			lines := 5 + rng.Intn(11)
+
+			var carrier any
+			if delivered {
+				carrier = 1 + rng.Intn(10)
+			}
+			if _, err := order.Exec(w, d, o, customers[(o-1)%TPCCCustomersPerDistrict]+1, now, carrier, lines, 1); err != nil {
+				return err
+			}
+			if !delivered {
+				if _, err := newOrder.Exec(w, d, o); err != nil {
+					return err
+				}
+			}
+
+			for n := 1; n <= lines; n++ {
+				var deliveryDate any
+				amount := 0.0
+				if delivered {
+					deliveryDate = now
+				} else {
+					amount = float64(1+rng.Intn(999999)) / 100
+				}
+				if _, err := line.Exec(w, d, o, n, 1+rng.Intn(TPCCItems), w, deliveryDate, 5, amount); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *sqlDriver) TPCCNewOrder(order TPCCNewOrder) error {
+	if s.tpcc == nil {
+		return ErrUnsupported
+	}
+
+	// Locking stock rows in item order keeps concurrent new-orders from
+	// deadlocking each other.
+	lines := append([]TPCCOrderLine(nil), order.Lines...)
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].SupplyWarehouseID != lines[j].SupplyWarehouseID {
+			return lines[i].SupplyWarehouseID < lines[j].SupplyWarehouseID
+		}
+		return lines[i].ItemID < lines[j].ItemID
+	})
+
+	return s.inTx(func(tx *sql.Tx) error {
+		var warehouseTax, districtTax, discount float64
+		var orderID int
+		var last, credit string
+
+		if err := tx.QueryRow(s.tpcc.selectWarehouseTax, order.WarehouseID).Scan(&warehouseTax); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(s.tpcc.nextOrderID, order.WarehouseID, order.DistrictID); err != nil {
+			return err
+		}
+		if err := tx.QueryRow(s.tpcc.selectDistrict, order.WarehouseID, order.DistrictID).Scan(&orderID, &districtTax); err != nil {
+			return err
+		}
+		if err := tx.QueryRow(s.tpcc.selectCustomer, order.WarehouseID, order.DistrictID, order.CustomerID).Scan(&discount, &last, &credit); err != nil {
+			return err
+		}
+
+		allLocal := 1
+		for _, line := range lines {
+			if line.SupplyWarehouseID != order.WarehouseID {
+				allLocal = 0
+			}
+		}
+		if _, err := tx.Exec(s.tpcc.insertOrder, order.WarehouseID, order.DistrictID, orderID, order.CustomerID, time.Now(), nil, len(lines), allLocal); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(s.tpcc.insertNewOrder, order.WarehouseID, order.DistrictID, orderID); err != nil {
+			return err
+		}
+
+		for n, line := range lines {
+			var price float64
+			if err := tx.QueryRow(s.tpcc.selectItemPrice, line.ItemID).Scan(&price); err != nil {
+				return err
+			}
+
+			remote := 0
+			if line.SupplyWarehouseID != order.WarehouseID {
+				remote = 1
+			}
+			q := line.Quantity
+			if _, err := tx.Exec(s.tpcc.updateStock, q, q, q, q, remote, line.SupplyWarehouseID, line.ItemID); err != nil {
+				return err
+			}
+
+			amount := float64(q) * price * (1 + warehouseTax + districtTax) * (1 - discount)
+			if _, err := tx.Exec(s.tpcc.insertOrderLine, order.WarehouseID, order.DistrictID, orderID, n+1,
+				line.ItemID, line.SupplyWarehouseID, nil, q, amount); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *sqlDriver) TPCCPayment(payment TPCCPayment) error {
+	if s.tpcc == nil {
+		return ErrUnsupported
+	}
+
+	return s.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(s.tpcc.payWarehouse, payment.Amount, payment.WarehouseID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(s.tpcc.payDistrict, payment.Amount, payment.WarehouseID, payment.DistrictID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(s.tpcc.payCustomer, payment.Amount, payment.Amount,
+			payment.WarehouseID, payment.DistrictID, payment.CustomerID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(s.tpcc.insertHistory, payment.CustomerID, payment.DistrictID, payment.WarehouseID,
+			payment.DistrictID, payment.WarehouseID, time.Now(), payment.Amount)
+		return err
+	})
+}
+
+func (s *sqlDriver) TPCCOrderStatus(warehouseID, districtID, customerID int) error {
+	if s.tpcc == nil {
+		return ErrUnsupported
+	}
+
+	return s.inReadTx(func(tx *sql.Tx) error {
+		var balance float64
+		var last string
+		if err := tx.QueryRow(s.tpcc.customerBalance, warehouseID, districtID, customerID).Scan(&balance, &last); err != nil {
+			return err
+		}
+
+		var orderID int
+		var entry time.Time
+		var carrier sql.NullInt64
+		err := tx.QueryRow(s.tpcc.lastOrder, warehouseID, districtID, customerID).Scan(&orderID, &entry, &carrier)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(s.tpcc.orderLines, warehouseID, districtID, orderID)
+		if err != nil {
+			return err
+		}
+		_, err = drainRows(rows)
+		return err
+	})
+}
+
+// TPCCDelivery delivers the oldest undelivered order of every district of the
+// warehouse. Districts whose oldest order was taken by a concurrent delivery
+// are skipped, as the specification allows.
+func (s *sqlDriver) TPCCDelivery(warehouseID, carrierID int) error {
+	if s.tpcc == nil {
+		return ErrUnsupported
+	}
+
+	return s.inTx(func(tx *sql.Tx) error {
+		now := time.Now()
+		for d := 1; d <= TPCCDistrictsPerWarehouse; d++ {
+			var oldest sql.NullInt64
+			if err := tx.QueryRow(s.tpcc.oldestNewOrder, warehouseID, d).Scan(&oldest); err != nil {
+				return err
+			}
+			if !oldest.Valid {
+				continue
+			}
+			orderID := oldest.Int64
+
+			res, err := tx.Exec(s.tpcc.deleteNewOrder, warehouseID, d, orderID)
+			if err != nil {
+				return err
+			}
+			if n, err := res.RowsAffected(); err == nil && n == 0 {
+				continue
+			}
+
+			var customerID int
+			if err := tx.QueryRow(s.tpcc.orderCustomer, warehouseID, d, orderID).Scan(&customerID); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(s.tpcc.setCarrier, carrierID, warehouseID, d, orderID); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(s.tpcc.deliverLines, now, warehouseID, d, orderID); err != nil {
+				return err
+			}
+			var total float64
+			if err := tx.QueryRow(s.tpcc.orderTotal, warehouseID, d, orderID).Scan(&total); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(s.tpcc.creditCustomer, total, warehouseID, d, customerID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TPCCStockLevel counts the distinct items of the district's last 20 orders
+// whose stock is below threshold.
+func (s *sqlDriver) TPCCStockLevel(warehouseID, districtID, threshold int) error {
+	if s.tpcc == nil {
+		return ErrUnsupported
+	}
+
+	return s.inReadTx(func(tx *sql.Tx) error {
+		var next int
+		if err := tx.QueryRow(s.tpcc.districtNextOrderID, warehouseID, districtID).Scan(&next); err != nil {
+			return err
+		}
+		var low int
+		return tx.QueryRow(s.tpcc.lowStock, warehouseID, districtID, next-20, next, threshold).Scan(&low)
+	})
+}
+
+// inTx runs fn in a transaction, committing when it succeeds and rolling back
+// otherwise.
+func (s *sqlDriver) inTx(fn func(tx *sql.Tx) error) error {
+	return s.transact(nil, fn)
+}
+
+// inReadTx is inTx for transactions that only read. SQLite starts them with a
+// deferred BEGIN instead of taking the write lock up front.
+func (s *sqlDriver) inReadTx(fn func(tx *sql.Tx) error) error {
+	return s.transact(&sql.TxOptions{ReadOnly: true}, fn)
+}
+
+func (s *sqlDriver) transact(opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			fmt.Printf("Warning: failed to rollback transaction: %v\n", err)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func closeStmt(stmt *sql.Stmt) {
+	if err := stmt.Close(); err != nil {
+		fmt.Printf("Warning: failed to close statement: %v\n", err)
+	}
+}
@@ -44,6 +44,12 @@ var (
 	}
 )
 
+// epoch anchors generated timestamps so equal seeds yield equal records
+// whenever they are generated.
+var epoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Generator produces random records and values. It is not safe for concurrent
+// use; goroutines take their own generator from Streams.
 type Generator struct {
 	rand *rand.Rand
 }
@@ -68,7 +74,7 @@ func (g *Generator) GenerateRecord(id int) models.TestRecord {
 		Email:       g.generateEmail(firstName, lastName, id),
 		Age:         g.rand.Intn(60) + 18,
 		Balance:     float64(g.rand.Intn(100000)) / 100,
-		CreatedAt:   time.Now().Add(-time.Duration(g.rand.Intn(365*24)) * time.Hour),
+		CreatedAt:   epoch.Add(-time.Duration(g.rand.Intn(365*24)) * time.Hour),
 		Description: g.generateDescription(),
 		IsActive:    g.rand.Float32() > 0.3,
 	}
@@ -172,3 +178,45 @@ func (g *Generator) GenerateUpdateValue(fieldType string) any {
 		return nil
 	}
 }
+
+func (g *Generator) GenerateInt(min, max int) int {
+	if max <= min {
+		return min
+	}
+	return min + g.rand.Intn(max-min+1)
+}
+
+func (g *Generator) GenerateFloat(min, max float64) float64 {
+	if max <= min {
+		return min
+	}
+	return min + g.rand.Float64()*(max-min)
+}
+
+// GenerateTimestamp returns a time within the year before epoch, at second
+// granularity.
+func (g *Generator) GenerateTimestamp() time.Time {
+	return epoch.Add(-time.Duration(g.rand.Intn(365*24*3600)) * time.Second)
+}
+
+func (g *Generator) GenerateBool() bool {
+	return g.rand.Intn(2) == 1
+}
+
+func (g *Generator) GenerateString(minLength, maxLength int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	length := g.GenerateInt(minLength, maxLength)
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = alphabet[g.rand.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+func (g *Generator) Pick(values []any) any {
+	if len(values) == 0 {
+		return nil
+	}
+	return values[g.rand.Intn(len(values))]
+}
@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"hash/fnv"
+	"math"
+	"sync/atomic"
+
+	"github.com/nadmax/dbcompare/internal/config"
+)
+
+// KeyChooser picks record keys in [1, n] following a request distribution.
+type KeyChooser interface {
+	Next(g *Generator) int
+}
+
+// Uniform picks every key in [1, Max] with equal probability.
+type Uniform struct {
+	Max int
+}
+
+func NewUniform(max int) *Uniform {
+	return &Uniform{Max: max}
+}
+
+func (u *Uniform) Next(g *Generator) int {
+	return g.GenerateRandomID(u.Max)
+}
+
+// DefaultZipfianTheta is the skew YCSB uses for its core workloads.
+const DefaultZipfianTheta = 0.99
+
+// Zipfian picks keys following a Zipf distribution where key 1 is the most
+// popular, using the algorithm of Gray et al., "Quickly Generating
+// Billion-Record Synthetic Databases". Scrambled variants hash the rank so
+// popular keys are spread across the key space instead of clustered at its
+// start.
+type Zipfian struct {
+	items     int
+	theta     float64
+	alpha     float64
+	zetan     float64
+	eta       float64
+	scrambled bool
+}
+
+func NewZipfian(items int, theta float64) *Zipfian {
+	items = max(items, 1)
+	zeta2 := zeta(2, theta)
+	zetan := zeta(items, theta)
+
+	return &Zipfian{
+		items: items,
+		theta: theta,
+		alpha: 1 / (1 - theta),
+		zetan: zetan,
+		eta:   (1 - math.Pow(2/float64(items), 1-theta)) / (1 - zeta2/zetan),
+	}
+}
+
+func NewScrambledZipfian(items int, theta float64) *Zipfian {
+	z := NewZipfian(items, theta)
+	z.scrambled = true
+	return z
+}
+
+func (z *Zipfian) Next(g *Generator) int {
+	rank := z.rank(g)
+	if z.scrambled {
+		rank = int(fnvHash(uint64(rank)) % uint64(z.items))
+	}
+	return rank + 1
+}
+
+// rank returns a zero-based popularity rank, 0 being the most popular.
+func (z *Zipfian) rank(g *Generator) int {
+	u := g.rand.Float64()
+	uz := u * z.zetan
+
+	if uz < 1 {
+		return 0
+	}
+	if uz < 1+math.Pow(0.5, z.theta) {
+		return 1
+	}
+	return min(int(float64(z.items)*math.Pow(z.eta*u-z.eta+1, z.alpha)), z.items-1)
+}
+
+// Latest favours the most recently inserted keys: the newest key is the most
+// popular and popularity decays along a Zipf distribution. Max tracks the
+// highest key and is shared with whatever inserts new records.
+type Latest struct {
+	zipfian *Zipfian
+	Max     *atomic.Int64
+}
+
+func NewLatest(items int, theta float64, maxKey *atomic.Int64) *Latest {
+	return &Latest{
+		zipfian: NewZipfian(items, theta),
+		Max:     maxKey,
+	}
+}
+
+func (l *Latest) Next(g *Generator) int {
+	return max(int(l.Max.Load())-l.zipfian.rank(g), 1)
+}
+
+// Hotspot sends a HotOps fraction of picks to the first HotKeys fraction of
+// the key space and spreads the rest uniformly over the remaining keys.
+type Hotspot struct {
+	Max     int
+	HotKeys float64
+	HotOps  float64
+}
+
+func NewHotspot(max int, hotKeys, hotOps float64) *Hotspot {
+	return &Hotspot{Max: max, HotKeys: hotKeys, HotOps: hotOps}
+}
+
+func (h *Hotspot) Next(g *Generator) int {
+	hot := min(max(int(float64(h.Max)*h.HotKeys), 1), h.Max)
+	if hot == h.Max || g.rand.Float64() < h.HotOps {
+		return 1 + g.rand.Intn(hot)
+	}
+	return hot + 1 + g.rand.Intn(h.Max-hot)
+}
+
+// Sequential walks the key space in order and wraps around after Max.
+type Sequential struct {
+	Max  int
+	next atomic.Int64
+}
+
+func NewSequential(max int) *Sequential {
+	return &Sequential{Max: max}
+}
+
+func (s *Sequential) Next(_ *Generator) int {
+	return int((s.next.Add(1)-1)%int64(max(s.Max, 1))) + 1
+}
+
+func zeta(n int, theta float64) float64 {
+	sum := 0.0
+	for i := 1; i <= n; i++ {
+		sum += 1 / math.Pow(float64(i), theta)
+	}
+	return sum
+}
+
+func fnvHash(v uint64) uint64 {
+	h := fnv.New64a()
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(v >> (8 * i))
+	}
+	_, _ = h.Write(b[:])
+	return h.Sum64()
+}
+
+// NewKeyChooser builds the chooser for a configured distribution. keys holds
+// the current number of keys; only latest follows it as keys are added, the
+// others size themselves on its value at construction.
+func NewKeyChooser(dist config.DistributionConfig, keys *atomic.Int64) KeyChooser {
+	count := int(keys.Load())
+	switch dist.Type {
+	case "zipfian":
+		return NewScrambledZipfian(count, dist.Theta)
+	case "hotspot":
+		return NewHotspot(count, dist.HotKeys, dist.HotOps)
+	case "latest":
+		return NewLatest(count, dist.Theta, keys)
+	case "sequential":
+		return NewSequential(count)
+	default:
+		return NewUniform(count)
+	}
+}
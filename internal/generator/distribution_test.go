@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nadmax/dbcompare/internal/config"
+)
+
+func TestKeyChoosersConcurrent(t *testing.T) {
+	const (
+		items   = 10000
+		workers = 8
+		draws   = 2000
+	)
+
+	for _, dist := range []config.DistributionConfig{
+		{Type: "uniform"},
+		{Type: "zipfian", Theta: DefaultZipfianTheta},
+		{Type: "hotspot", HotKeys: 0.2, HotOps: 0.8},
+		{Type: "latest", Theta: DefaultZipfianTheta},
+		{Type: "sequential"},
+	} {
+		t.Run(dist.Type, func(t *testing.T) {
+			var keys atomic.Int64
+			keys.Store(items)
+			chooser := NewKeyChooser(dist, &keys)
+			gens := NewStreams(7).Workers(dist.Type, workers)
+
+			var wg sync.WaitGroup
+			var outOfRange atomic.Int64
+			for w := range workers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range draws {
+						// Inserts grow the key space while readers pick keys,
+						// as concurrent writes do for latest.
+						if w == 0 {
+							keys.Add(1)
+						}
+						if key := chooser.Next(gens[w]); key < 1 || key > int(keys.Load()) {
+							outOfRange.Add(1)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			if n := outOfRange.Load(); n > 0 {
+				t.Fatalf("%d keys out of range", n)
+			}
+		})
+	}
+}
+
+func TestZeta(t *testing.T) {
+	tests := []struct {
+		n     int
+		theta float64
+		want  float64
+	}{
+		{1, DefaultZipfianTheta, 1},
+		{2, DefaultZipfianTheta, 1 + math.Pow(2, -DefaultZipfianTheta)},
+		{10, 1, 7381.0 / 2520}, // the 10th harmonic number
+		{100, 0, 100},
+		{4, 2, 1 + 1.0/4 + 1.0/9 + 1.0/16},
+	}
+
+	for _, tt := range tests {
+		if got := zeta(tt.n, tt.theta); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("zeta(%d, %g) = %.12f, want %.12f", tt.n, tt.theta, got, tt.want)
+		}
+	}
+}
+
+// frequencies draws n keys and returns how often each key came up.
+func frequencies(chooser KeyChooser, n int) map[int]float64 {
+	gen := New(42)
+	counts := make(map[int]float64)
+	for range n {
+		counts[chooser.Next(gen)]++
+	}
+	for k := range counts {
+		counts[k] /= float64(n)
+	}
+	return counts
+}
+
+func TestZipfianFrequencies(t *testing.T) {
+	const (
+		items = 1000
+		draws = 200000
+	)
+	zetan := zeta(items, DefaultZipfianTheta)
+
+	// The two most popular keys are drawn exactly with probabilities 1/zeta(n)
+	// and 2^-theta/zeta(n).
+	freq := frequencies(NewZipfian(items, DefaultZipfianTheta), draws)
+	for _, tt := range []struct {
+		key  int
+		want float64
+	}{
+		{1, 1 / zetan},
+		{2, math.Pow(2, -DefaultZipfianTheta) / zetan},
+	} {
+		if got := freq[tt.key]; math.Abs(got-tt.want) > 0.005 {
+			t.Errorf("key %d drawn %.4f of the time, want %.4f", tt.key, got, tt.want)
+		}
+	}
+
+	// Latest mirrors the ranks onto the newest keys.
+	var keys atomic.Int64
+	keys.Store(items)
+	freq = frequencies(NewLatest(items, DefaultZipfianTheta, &keys), draws)
+	if got, want := freq[items], 1/zetan; math.Abs(got-want) > 0.005 {
+		t.Errorf("newest key drawn %.4f of the time, want %.4f", got, want)
+	}
+}
+
+func TestHotspotFraction(t *testing.T) {
+	const items = 1000
+	freq := frequencies(NewHotspot(items, 0.2, 0.8), 100000)
+
+	hot := 0.0
+	for key, f := range freq {
+		if key < 1 || key > items {
+			t.Fatalf("key %d out of range", key)
+		}
+		if key <= 200 {
+			hot += f
+		}
+	}
+	if math.Abs(hot-0.8) > 0.01 {
+		t.Errorf("hot keys drawn %.4f of the time, want 0.8", hot)
+	}
+}
+
+func TestUniformRange(t *testing.T) {
+	const items = 100
+	freq := frequencies(NewUniform(items), 100000)
+
+	if len(freq) != items {
+		t.Fatalf("drew %d distinct keys, want %d", len(freq), items)
+	}
+	mean := 0.0
+	for key, f := range freq {
+		if key < 1 || key > items {
+			t.Fatalf("key %d out of range", key)
+		}
+		mean += float64(key) * f
+	}
+	if want := float64(items+1) / 2; math.Abs(mean-want) > 0.5 {
+		t.Errorf("mean key %.2f, want %.2f", mean, want)
+	}
+}
+
+func TestSequentialWraps(t *testing.T) {
+	s := NewSequential(3)
+	want := []int{1, 2, 3, 1, 2, 3, 1}
+	for i, w := range want {
+		if got := s.Next(nil); got != w {
+			t.Fatalf("draw %d = %d, want %d", i, got, w)
+		}
+	}
+}
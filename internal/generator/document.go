@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/models"
+)
+
+var (
+	cities = []string{
+		"Paris", "London", "Berlin", "Madrid", "Rome", "Lisbon", "Vienna", "Prague",
+		"Warsaw", "Dublin", "Oslo", "Stockholm", "Helsinki", "Athens", "Brussels", "Zurich",
+	}
+
+	countries = []string{"FR", "GB", "DE", "ES", "IT", "PT", "AT", "CZ", "PL", "IE", "NO", "SE", "FI", "GR", "BE", "CH"}
+
+	tiers = []string{"bronze", "silver", "gold", "platinum"}
+
+	tags = []string{
+		"sports", "music", "travel", "books", "cooking", "gaming", "movies", "fashion",
+		"finance", "health", "science", "art", "outdoors", "tech", "pets", "family",
+	}
+
+	eventTypes = []string{"login", "purchase", "refund", "review", "support", "upgrade"}
+)
+
+// documentBaseSize approximates the encoded size of a document without events,
+// and eventOverhead that of an event without its note.
+const (
+	documentBaseSize = 320
+	eventOverhead    = 90
+)
+
+// GenerateDocumentSize draws a target document size in bytes, log-uniformly
+// between min and max so small and large documents are equally represented.
+func (g *Generator) GenerateDocumentSize(min, max int) int {
+	if max <= min {
+		return min
+	}
+	lo, hi := math.Log(float64(min)), math.Log(float64(max))
+	return int(math.Exp(lo + g.rand.Float64()*(hi-lo)))
+}
+
+// GenerateDocument builds a document of roughly size bytes once encoded as
+// JSON, padding it with events until the size is reached.
+func (g *Generator) GenerateDocument(id, size int) models.Document {
+	firstName := firstNames[g.rand.Intn(len(firstNames))]
+	lastName := lastNames[g.rand.Intn(len(lastNames))]
+	city := g.rand.Intn(len(cities))
+
+	doc := models.Document{
+		ID:    id,
+		Owner: fmt.Sprintf("%s %s", firstName, lastName),
+		Profile: models.DocumentProfile{
+			Name:  fmt.Sprintf("%s %s", firstName, lastName),
+			Email: g.generateEmail(firstName, lastName, id),
+			Address: models.DocumentAddress{
+				Street:  fmt.Sprintf("%d %s Street", 1+g.rand.Intn(200), lastNames[g.rand.Intn(len(lastNames))]),
+				City:    cities[city],
+				Country: countries[city],
+			},
+			Preferences: models.DocumentPreferences{
+				Tier:       g.DocumentTier(),
+				Tags:       g.documentTags(),
+				Newsletter: g.GenerateBool(),
+			},
+		},
+	}
+
+	for remaining := size - documentBaseSize; remaining > 0; {
+		event := g.GenerateDocumentEvent(min(max(remaining-eventOverhead, 16), 1024))
+		doc.Events = append(doc.Events, event)
+		remaining -= eventOverhead + len(event.Note)
+	}
+	if doc.Events == nil {
+		doc.Events = []models.DocumentEvent{}
+	}
+	return doc
+}
+
+// GenerateDocumentEvent builds an event whose note is at most noteLength long.
+func (g *Generator) GenerateDocumentEvent(noteLength int) models.DocumentEvent {
+	return models.DocumentEvent{
+		Type:   eventTypes[g.rand.Intn(len(eventTypes))],
+		At:     epoch.Add(-time.Duration(g.rand.Intn(365*24*3600)) * time.Second).Format(time.RFC3339),
+		Amount: float64(g.rand.Intn(100000)) / 100,
+		Note:   g.GenerateString(max(noteLength/2, 1), noteLength),
+	}
+}
+
+func (g *Generator) DocumentCity() string {
+	return cities[g.rand.Intn(len(cities))]
+}
+
+func (g *Generator) DocumentTag() string {
+	return tags[g.rand.Intn(len(tags))]
+}
+
+func (g *Generator) DocumentTier() string {
+	return tiers[g.rand.Intn(len(tiers))]
+}
+
+func (g *Generator) documentTags() []string {
+	picked := make([]string, 0, 4)
+	for _, i := range g.rand.Perm(len(tags))[:1+g.rand.Intn(4)] {
+		picked = append(picked, tags[i])
+	}
+	return picked
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// DeriveSeed mixes labels into a root seed, giving every operation and worker
+// its own reproducible stream. Equal labels always yield the same seed, so
+// separate generators built from it produce identical sequences.
+func DeriveSeed(root int64, labels ...any) int64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprint(h, root)
+	for _, label := range labels {
+		_, _ = fmt.Fprintf(h, "/%v", label)
+	}
+	return int64(h.Sum64())
+}
+
+// Streams hands out generators derived from one root seed. A Generator is not
+// safe for concurrent use, so concurrent code takes one per goroutine instead
+// of sharing it; Streams itself holds no mutable state and may be shared.
+type Streams struct {
+	root int64
+}
+
+func NewStreams(root int64) *Streams {
+	return &Streams{root: root}
+}
+
+// Pass returns the streams of one pass over the operations, such as a warmup
+// or a measured iteration. Each pass draws fresh records and keys instead of
+// replaying the previous one, while equal labels still give every engine the
+// same streams.
+func (s *Streams) Pass(labels ...any) *Streams {
+	return &Streams{root: DeriveSeed(s.root, labels...)}
+}
+
+// Generator returns the generator of one worker of an operation.
+func (s *Streams) Generator(operation string, worker int) *Generator {
+	return New(DeriveSeed(s.root, operation, worker))
+}
+
+// Workers returns one generator per worker of an operation, indexed by worker.
+func (s *Streams) Workers(operation string, workers int) []*Generator {
+	gens := make([]*Generator, workers)
+	for w := range gens {
+		gens[w] = s.Generator(operation, w)
+	}
+	return gens
+}
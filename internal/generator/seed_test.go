@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestStreamsWorkersConcurrent(t *testing.T) {
+	const (
+		workers = 8
+		draws   = 1000
+	)
+	streams := NewStreams(42)
+
+	want := make([][]int, workers)
+	for w, gen := range streams.Workers("Concurrent Reads", workers) {
+		for range draws {
+			want[w] = append(want[w], gen.GenerateRandomID(1_000_000))
+		}
+	}
+
+	got := make([][]int, workers)
+	gens := streams.Workers("Concurrent Reads", workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range draws {
+				got[w] = append(got[w], gens[w].GenerateRandomID(1_000_000))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for w := range workers {
+		for i := range draws {
+			if got[w][i] != want[w][i] {
+				t.Fatalf("worker %d draw %d = %d, want %d", w, i, got[w][i], want[w][i])
+			}
+		}
+	}
+}
+
+func TestStreamsIndependent(t *testing.T) {
+	streams := NewStreams(42)
+	a := streams.Generator("Random Read", 0).GenerateRandomID(1_000_000)
+	b := streams.Generator("Random Read", 1).GenerateRandomID(1_000_000)
+	c := streams.Generator("Update Operations", 0).GenerateRandomID(1_000_000)
+	if a == b && b == c {
+		t.Fatalf("workers and operations drew the same key %d", a)
+	}
+	if again := streams.Generator("Random Read", 0).GenerateRandomID(1_000_000); again != a {
+		t.Fatalf("same stream drew %d, then %d", a, again)
+	}
+}
+
+func TestStreamsPasses(t *testing.T) {
+	draw := func(s *Streams) []int {
+		gen := s.Generator("Random Read", 0)
+		keys := make([]int, 10)
+		for i := range keys {
+			keys[i] = gen.GenerateRandomID(1_000_000)
+		}
+		return keys
+	}
+
+	root := NewStreams(42)
+	warmup := draw(root.Pass("warmup", 0))
+	first := draw(root.Pass("iteration", 0))
+	second := draw(root.Pass("iteration", 1))
+	if slices.Equal(warmup, first) || slices.Equal(first, second) {
+		t.Fatalf("passes replayed the same keys: warmup %v, iterations %v and %v", warmup, first, second)
+	}
+	if again := draw(NewStreams(42).Pass("iteration", 1)); !slices.Equal(again, second) {
+		t.Fatalf("same pass drew %v, then %v", second, again)
+	}
+}
@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/config"
+)
+
+// Table generates the rows of a custom table. Values follow the table's column
+// order; NULL is returned as nil and timestamps as time.Time.
+type Table struct {
+	config  config.TableConfig
+	columns []tableColumn
+}
+
+type tableColumn struct {
+	config.ColumnConfig
+	// keys picks the rank of a value among Cardinality distinct ones, or the
+	// referenced primary key of a foreign key.
+	keys KeyChooser
+}
+
+// NewTable prepares the generator of a table. rows gives the row count of
+// every table, which sizes the foreign keys.
+func NewTable(table config.TableConfig, rows map[string]int) *Table {
+	t := &Table{config: table}
+	for _, column := range table.Columns {
+		c := tableColumn{ColumnConfig: column}
+
+		count := column.Cardinality
+		if column.References != "" {
+			count = rows[column.ReferencedTable()]
+		}
+		if count > 0 && !column.PrimaryKey {
+			var keys atomic.Int64
+			keys.Store(int64(count))
+			c.keys = NewKeyChooser(column.Distribution, &keys)
+		}
+		t.columns = append(t.columns, c)
+	}
+	return t
+}
+
+// Row generates the n-th row of the table, numbered from 1.
+func (t *Table) Row(g *Generator, n int) []any {
+	row := make([]any, len(t.columns))
+	for i := range t.columns {
+		row[i] = t.value(g, &t.columns[i], n)
+	}
+	return row
+}
+
+// Rows generates count rows starting at row start.
+func (t *Table) Rows(g *Generator, count, start int) [][]any {
+	rows := make([][]any, count)
+	for i := range rows {
+		rows[i] = t.Row(g, start+i)
+	}
+	return rows
+}
+
+func (t *Table) value(g *Generator, c *tableColumn, n int) any {
+	switch {
+	case c.PrimaryKey:
+		return n
+	case c.Nullable > 0 && g.rand.Float64() < c.Nullable:
+		return nil
+	case c.References != "":
+		return c.keys.Next(g)
+	case c.keys != nil:
+		return rankedValue(&c.ColumnConfig, c.keys.Next(g))
+	}
+
+	switch c.Type {
+	case "int":
+		return g.GenerateInt(int(c.Min), int(c.Max))
+	case "float":
+		return math.Round(g.GenerateFloat(c.Min, c.Max)*100) / 100
+	case "string":
+		return g.GenerateString(c.MinLength, c.MaxLength)
+	case "bool":
+		return g.GenerateBool()
+	default:
+		return epoch.Add(-time.Duration(g.rand.Intn(365*24*3600)) * time.Second)
+	}
+}
+
+// rankedValue returns the rank-th of a column's Cardinality distinct values.
+// Values are spread evenly over the column's range and depend only on the
+// column and the rank, so every worker and engine agrees on them.
+func rankedValue(c *config.ColumnConfig, rank int) any {
+	fraction := float64(rank-1) / float64(max(c.Cardinality-1, 1))
+	switch c.Type {
+	case "int":
+		return int(c.Min) + int(math.Round(fraction*(c.Max-c.Min)))
+	case "float":
+		return math.Round((c.Min+fraction*(c.Max-c.Min))*100) / 100
+	case "string":
+		return rankedString(c, rank)
+	case "bool":
+		return rank%2 == 1
+	default:
+		return epoch.Add(-time.Duration(fraction*365*24*3600) * time.Second)
+	}
+}
+
+func rankedString(c *config.ColumnConfig, rank int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	h := fnvHash(uint64(DeriveSeed(int64(rank), c.Name)))
+	length := c.MinLength + int(h%uint64(c.MaxLength-c.MinLength+1))
+	b := make([]byte, length)
+	for i := range b {
+		h = fnvHash(h)
+		b[i] = alphabet[h%uint64(len(alphabet))]
+	}
+	return string(b)
+}
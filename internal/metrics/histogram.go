@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+)
+
+// DefaultPercentiles are reported when the configuration does not list any.
+var DefaultPercentiles = []float64{50, 90, 99, 99.9}
+
+const (
+	lowestTrackable  = 1                                   // 1µs
+	highestTrackable = int64(time.Hour / time.Microsecond) // 1h
+	significantFigs  = 3
+)
+
+// Histogram is safe for concurrent use.
+type Histogram struct {
+	mu   sync.Mutex
+	hist *hdrhistogram.Histogram
+}
+
+type Percentile struct {
+	Percentile float64       `json:"percentile"`
+	Value      time.Duration `json:"value"`
+}
+
+type LatencyStats struct {
+	Count       int64         `json:"count"`
+	Min         time.Duration `json:"min"`
+	Mean        time.Duration `json:"mean"`
+	Max         time.Duration `json:"max"`
+	StdDev      time.Duration `json:"stddev"`
+	Percentiles []Percentile  `json:"percentiles"`
+}
+
+func NewHistogram() *Histogram {
+	return &Histogram{
+		hist: hdrhistogram.New(lowestTrackable, highestTrackable, significantFigs),
+	}
+}
+
+func (h *Histogram) Record(d time.Duration) {
+	us := max(d.Microseconds(), lowestTrackable)
+	us = min(us, highestTrackable)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_ = h.hist.RecordValue(us)
+}
+
+func (h *Histogram) Merge(other *Histogram) {
+	if other == nil || other == h {
+		return
+	}
+
+	other.mu.Lock()
+	snapshot := hdrhistogram.Import(other.hist.Export())
+	other.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hist.Merge(snapshot)
+}
+
+func (h *Histogram) Count() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.hist.TotalCount()
+}
+
+func (h *Histogram) Stats(percentiles []float64) *LatencyStats {
+	if len(percentiles) == 0 {
+		percentiles = DefaultPercentiles
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.hist.TotalCount() == 0 {
+		return nil
+	}
+
+	stats := &LatencyStats{
+		Count:       h.hist.TotalCount(),
+		Min:         toDuration(float64(h.hist.Min())),
+		Mean:        toDuration(h.hist.Mean()),
+		Max:         toDuration(float64(h.hist.Max())),
+		StdDev:      toDuration(h.hist.StdDev()),
+		Percentiles: make([]Percentile, 0, len(percentiles)),
+	}
+	for _, p := range percentiles {
+		stats.Percentiles = append(stats.Percentiles, Percentile{
+			Percentile: p,
+			Value:      toDuration(float64(h.hist.ValueAtPercentile(p))),
+		})
+	}
+
+	return stats
+}
+
+// PercentileLabel formats a percentile as p50, p99.9, ...
+func PercentileLabel(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+func toDuration(us float64) time.Duration {
+	return time.Duration(math.Round(us * float64(time.Microsecond)))
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type TimelineSample struct {
+	Elapsed    time.Duration `json:"elapsed"`
+	Operations int64         `json:"operations"`
+	Throughput float64       `json:"throughput"`
+}
+
+// Timeline samples how many operations completed during each interval.
+type Timeline struct {
+	interval time.Duration
+	count    atomic.Int64
+	start    time.Time
+	samples  []TimelineSample
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+func NewTimeline(interval time.Duration) *Timeline {
+	return &Timeline{
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (t *Timeline) Start() {
+	t.start = time.Now()
+	t.done.Go(func() {
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		last := t.start
+		for {
+			select {
+			case now := <-ticker.C:
+				t.sample(now, last)
+				last = now
+			case <-t.stop:
+				if now := time.Now(); now.Sub(last) > t.interval/10 {
+					t.sample(now, last)
+				}
+				return
+			}
+		}
+	})
+}
+
+func (t *Timeline) Add(n int64) {
+	t.count.Add(n)
+}
+
+func (t *Timeline) Stop() []TimelineSample {
+	close(t.stop)
+	t.done.Wait()
+	return t.samples
+}
+
+func (t *Timeline) sample(now, last time.Time) {
+	ops := t.count.Swap(0)
+	t.samples = append(t.samples, TimelineSample{
+		Elapsed:    now.Sub(t.start),
+		Operations: ops,
+		Throughput: float64(ops) / now.Sub(last).Seconds(),
+	})
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/metrics"
+)
+
+// OperationBreakdown is the share of one operation type inside a result that
+// mixes several operation types.
+type OperationBreakdown struct {
+	Operation  string                `json:"operation"`
+	Count      int                   `json:"count"`
+	Share      float64               `json:"share"`
+	Throughput float64               `json:"throughput"`
+	ErrorCount int                   `json:"error_count"`
+	ErrorRate  float64               `json:"error_rate"`
+	Latency    *metrics.LatencyStats `json:"latency,omitempty"`
+}
+
+type operationPart struct {
+	name      string
+	calls     atomic.Int64
+	errors    atomic.Int64
+	histogram *metrics.Histogram
+}
+
+func newOperationPart(name string) *operationPart {
+	return &operationPart{name: name, histogram: metrics.NewHistogram()}
+}
+
+// TrackOperations declares the operation types a mixed result is broken down
+// into. It must be called before any call is recorded.
+func (r *BenchmarkResult) TrackOperations(names ...string) {
+	for _, name := range names {
+		r.parts = append(r.parts, newOperationPart(name))
+	}
+}
+
+// RecordOperation adds one call of a tracked operation type to its breakdown.
+// The overall histogram is left to Observe.
+func (r *BenchmarkResult) RecordOperation(name string, d time.Duration, err error) {
+	for _, part := range r.parts {
+		if part.name == name {
+			part.histogram.Record(d)
+			part.calls.Add(1)
+			if err != nil {
+				part.errors.Add(1)
+			}
+			return
+		}
+	}
+}
+
+func (r *BenchmarkResult) completeBreakdown() {
+	if len(r.parts) == 0 {
+		return
+	}
+
+	total := int64(0)
+	for _, part := range r.parts {
+		total += part.calls.Load()
+	}
+
+	r.Breakdown = make([]OperationBreakdown, 0, len(r.parts))
+	for _, part := range r.parts {
+		r.Breakdown = append(r.Breakdown, part.breakdown(total, r.Duration, r.percentiles))
+	}
+}
+
+func (p *operationPart) breakdown(total int64, duration time.Duration, percentiles []float64) OperationBreakdown {
+	calls := p.calls.Load()
+	b := OperationBreakdown{
+		Operation:  p.name,
+		Count:      int(calls),
+		ErrorCount: int(p.errors.Load()),
+		Latency:    p.histogram.Stats(percentiles),
+	}
+	if total > 0 {
+		b.Share = float64(calls) / float64(total)
+	}
+	if calls > 0 {
+		b.ErrorRate = float64(b.ErrorCount) / float64(calls)
+	}
+	if duration > 0 {
+		b.Throughput = float64(calls) / duration.Seconds()
+	}
+	return b
+}
+
+// aggregateBreakdown merges the breakdowns of repeated runs. Call and error
+// counts are averaged per run; share, error rate and throughput cover all runs,
+// throughput being every call divided by the total duration. Latency
+// histograms are merged.
+func aggregateBreakdown(aggregated *BenchmarkResult, results []*BenchmarkResult) {
+	first := results[0]
+	if len(first.parts) == 0 {
+		return
+	}
+
+	var totalDuration time.Duration
+	for _, r := range results {
+		totalDuration += r.Duration
+	}
+
+	for _, p := range first.parts {
+		merged := newOperationPart(p.name)
+		for _, r := range results {
+			for _, part := range r.parts {
+				if part.name != p.name {
+					continue
+				}
+				merged.calls.Add(part.calls.Load())
+				merged.errors.Add(part.errors.Load())
+				merged.histogram.Merge(part.histogram)
+			}
+		}
+		aggregated.parts = append(aggregated.parts, merged)
+	}
+
+	total := int64(0)
+	for _, part := range aggregated.parts {
+		total += part.calls.Load()
+	}
+
+	runs := len(results)
+	aggregated.Breakdown = make([]OperationBreakdown, 0, len(aggregated.parts))
+	for _, part := range aggregated.parts {
+		b := part.breakdown(total, totalDuration, aggregated.percentiles)
+		b.Count /= runs
+		b.ErrorCount /= runs
+		aggregated.Breakdown = append(aggregated.Breakdown, b)
+	}
+}
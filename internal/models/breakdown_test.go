@@ -0,0 +1,64 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// mixedRun records calls[name] calls of each operation, fails[name] of them
+// failing, into a result that took duration.
+func mixedRun(duration time.Duration, calls, fails map[string]int) *BenchmarkResult {
+	r := NewBenchmarkResult("Mixed", "postgres", 0)
+	r.TrackOperations("read", "update")
+	for _, name := range []string{"read", "update"} {
+		for i := range calls[name] {
+			var err error
+			if i < fails[name] {
+				err = errors.New("failed")
+			}
+			r.RecordOperation(name, time.Millisecond, err)
+		}
+	}
+	r.Duration = duration
+	r.completeBreakdown()
+	return r
+}
+
+func TestAggregateBreakdown(t *testing.T) {
+	runs := []*BenchmarkResult{
+		mixedRun(time.Second, map[string]int{"read": 80, "update": 20}, map[string]int{"update": 2}),
+		mixedRun(3*time.Second, map[string]int{"read": 160, "update": 40}, map[string]int{"update": 4}),
+	}
+	aggregated := AggregateResults(runs, 0)
+
+	// Counts are per run, throughput is all calls over the total duration.
+	want := []struct {
+		operation  string
+		count      int
+		errorCount int
+		share      float64
+		throughput float64
+		errorRate  float64
+	}{
+		{"read", 120, 0, 0.8, 60, 0},
+		{"update", 30, 3, 0.2, 15, 0.1},
+	}
+
+	if len(aggregated.Breakdown) != len(want) {
+		t.Fatalf("got %d breakdown entries, want %d", len(aggregated.Breakdown), len(want))
+	}
+	for i, w := range want {
+		got := aggregated.Breakdown[i]
+		if got.Operation != w.operation || got.Count != w.count || got.ErrorCount != w.errorCount ||
+			math.Abs(got.Share-w.share) > 1e-9 ||
+			math.Abs(got.Throughput-w.throughput) > 1e-9 ||
+			math.Abs(got.ErrorRate-w.errorRate) > 1e-9 {
+			t.Errorf("breakdown %d = %+v, want %+v", i, got, w)
+		}
+		if got.Latency == nil || got.Latency.Count != int64(2*w.count) {
+			t.Errorf("%s: latency %+v, want %d merged samples", w.operation, got.Latency, 2*w.count)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+// Document is the nested payload of the documents suite. Its size is governed
+// by the number and length of its events.
+type Document struct {
+	ID      int             `json:"id"`
+	Owner   string          `json:"owner"`
+	Profile DocumentProfile `json:"profile"`
+	Events  []DocumentEvent `json:"events"`
+}
+
+type DocumentProfile struct {
+	Name        string              `json:"name"`
+	Email       string              `json:"email"`
+	Address     DocumentAddress     `json:"address"`
+	Preferences DocumentPreferences `json:"preferences"`
+}
+
+type DocumentAddress struct {
+	Street  string `json:"street"`
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type DocumentPreferences struct {
+	Tier       string   `json:"tier"`
+	Tags       []string `json:"tags"`
+	Newsletter bool     `json:"newsletter"`
+}
+
+// DocumentEvent is one entry of a document's history. At is kept as an
+// RFC 3339 string so every engine stores it the same way.
+type DocumentEvent struct {
+	Type   string  `json:"type"`
+	At     string  `json:"at"`
+	Amount float64 `json:"amount"`
+	Note   string  `json:"note"`
+}
@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/nadmax/dbcompare/internal/metrics"
+	"github.com/nadmax/dbcompare/internal/stats"
+)
 
 type TestRecord struct {
 	ID          int       `json:"id"`
@@ -14,16 +19,31 @@ type TestRecord struct {
 }
 
 type BenchmarkResult struct {
-	Operation    string         `json:"operation"`
-	Database     string         `json:"database"`
-	Duration     time.Duration  `json:"duration"`
-	RecordsCount int            `json:"records_count"`
-	Throughput   float64        `json:"throughput"`
-	ErrorCount   int            `json:"error_count"`
-	ErrorRate    float64        `json:"error_rate"`
-	StartTime    time.Time      `json:"start_time"`
-	EndTime      time.Time      `json:"end_time"`
-	Metadata     map[string]any `json:"metadata,omitempty"`
+	Operation    string                   `json:"operation"`
+	Database     string                   `json:"database"`
+	Duration     time.Duration            `json:"duration"`
+	RecordsCount int                      `json:"records_count"`
+	Throughput   float64                  `json:"throughput"`
+	ErrorCount   int                      `json:"error_count"`
+	ErrorRate    float64                  `json:"error_rate"`
+	StartTime    time.Time                `json:"start_time"`
+	EndTime      time.Time                `json:"end_time"`
+	Latency      *metrics.LatencyStats    `json:"latency,omitempty"`
+	Iterations   *IterationStats          `json:"iterations,omitempty"`
+	Timeline     []metrics.TimelineSample `json:"timeline,omitempty"`
+	Breakdown    []OperationBreakdown     `json:"breakdown,omitempty"`
+	Metadata     map[string]any           `json:"metadata,omitempty"`
+
+	histogram   *metrics.Histogram
+	percentiles []float64
+	parts       []*operationPart
+}
+
+type IterationStats struct {
+	Warmup     int           `json:"warmup"`
+	Count      int           `json:"count"`
+	Throughput stats.Summary `json:"throughput"`
+	Duration   stats.Summary `json:"duration_seconds"`
 }
 
 type BenchmarkSuite struct {
@@ -32,6 +52,8 @@ type BenchmarkSuite struct {
 	EndTime   time.Time         `json:"end_time"`
 	Duration  time.Duration     `json:"duration"`
 	Config    map[string]any    `json:"config"`
+	Scaling   []ScalingCurve    `json:"scaling,omitempty"`
+	Parity    []ParityGap       `json:"parity,omitempty"`
 }
 
 func NewBenchmarkResult(operation, database string, recordsCount int) *BenchmarkResult {
@@ -41,6 +63,7 @@ func NewBenchmarkResult(operation, database string, recordsCount int) *Benchmark
 		RecordsCount: recordsCount,
 		StartTime:    time.Now(),
 		Metadata:     make(map[string]any),
+		histogram:    metrics.NewHistogram(),
 	}
 }
 
@@ -53,8 +76,73 @@ func (r *BenchmarkResult) Complete(errorCount int) {
 		r.ErrorRate = float64(errorCount) / float64(r.RecordsCount)
 		r.Throughput = float64(r.RecordsCount) / r.Duration.Seconds()
 	}
+
+	r.Latency = r.histogram.Stats(r.percentiles)
+	r.completeBreakdown()
+}
+
+func (r *BenchmarkResult) SetPercentiles(percentiles []float64) {
+	r.percentiles = percentiles
+}
+
+func (r *BenchmarkResult) RecordLatency(d time.Duration) {
+	r.histogram.Record(d)
+}
+
+// Observe records the latency of a call that started at start.
+func (r *BenchmarkResult) Observe(start time.Time) {
+	r.histogram.Record(time.Since(start))
 }
 
 func (r *BenchmarkResult) SetMetadata(key string, value any) {
 	r.Metadata[key] = value
 }
+
+// AggregateResults folds the measured repetitions of one operation into a
+// single result. Throughput and Duration become the mean across repetitions,
+// error counts are summed and latency histograms are merged.
+func AggregateResults(results []*BenchmarkResult, warmup int) *BenchmarkResult {
+	if len(results) == 0 {
+		return nil
+	}
+
+	first := results[0]
+	aggregated := &BenchmarkResult{
+		Operation:    first.Operation,
+		Database:     first.Database,
+		RecordsCount: first.RecordsCount,
+		StartTime:    first.StartTime,
+		EndTime:      results[len(results)-1].EndTime,
+		Metadata:     results[len(results)-1].Metadata,
+		Timeline:     results[len(results)-1].Timeline,
+		histogram:    metrics.NewHistogram(),
+		percentiles:  first.percentiles,
+	}
+
+	throughputs := make([]float64, 0, len(results))
+	durations := make([]float64, 0, len(results))
+	totalRecords := 0
+	for _, r := range results {
+		throughputs = append(throughputs, r.Throughput)
+		durations = append(durations, r.Duration.Seconds())
+		totalRecords += r.RecordsCount
+		aggregated.ErrorCount += r.ErrorCount
+		aggregated.histogram.Merge(r.histogram)
+	}
+
+	aggregated.Iterations = &IterationStats{
+		Warmup:     warmup,
+		Count:      len(results),
+		Throughput: stats.Summarize(throughputs),
+		Duration:   stats.Summarize(durations),
+	}
+	aggregated.Throughput = aggregated.Iterations.Throughput.Mean
+	aggregated.Duration = time.Duration(aggregated.Iterations.Duration.Mean * float64(time.Second))
+	if totalRecords > 0 {
+		aggregated.ErrorRate = float64(aggregated.ErrorCount) / float64(totalRecords)
+	}
+	aggregated.Latency = aggregated.histogram.Stats(aggregated.percentiles)
+	aggregateBreakdown(aggregated, results)
+
+	return aggregated
+}
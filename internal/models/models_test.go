@@ -0,0 +1,90 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// repetition builds a completed run with the given figures and latencies.
+func repetition(throughput float64, duration time.Duration, records, errors int, latencies ...time.Duration) *BenchmarkResult {
+	r := NewBenchmarkResult("Random Read", "postgres", records)
+	for _, l := range latencies {
+		r.RecordLatency(l)
+	}
+	r.Throughput = throughput
+	r.Duration = duration
+	r.ErrorCount = errors
+	return r
+}
+
+func TestAggregateResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		runs       []*BenchmarkResult
+		throughput float64
+		duration   time.Duration
+		errorCount int
+		errorRate  float64
+		latencies  int64
+	}{
+		{
+			name:       "single run",
+			runs:       []*BenchmarkResult{repetition(500, 2*time.Second, 1000, 10, time.Millisecond)},
+			throughput: 500,
+			duration:   2 * time.Second,
+			errorCount: 10,
+			errorRate:  0.01,
+			latencies:  1,
+		},
+		{
+			// Throughput and duration are averaged, errors and latencies
+			// summed over all runs.
+			name: "three runs",
+			runs: []*BenchmarkResult{
+				repetition(100, 1*time.Second, 200, 0, time.Millisecond, 2*time.Millisecond),
+				repetition(200, 2*time.Second, 200, 2, 3*time.Millisecond),
+				repetition(300, 3*time.Second, 200, 4, 4*time.Millisecond),
+			},
+			throughput: 200,
+			duration:   2 * time.Second,
+			errorCount: 6,
+			errorRate:  0.01,
+			latencies:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AggregateResults(tt.runs, 1)
+
+			if math.Abs(got.Throughput-tt.throughput) > 1e-9 {
+				t.Errorf("Throughput = %g, want %g", got.Throughput, tt.throughput)
+			}
+			if got.Duration != tt.duration {
+				t.Errorf("Duration = %v, want %v", got.Duration, tt.duration)
+			}
+			if got.ErrorCount != tt.errorCount {
+				t.Errorf("ErrorCount = %d, want %d", got.ErrorCount, tt.errorCount)
+			}
+			if math.Abs(got.ErrorRate-tt.errorRate) > 1e-9 {
+				t.Errorf("ErrorRate = %g, want %g", got.ErrorRate, tt.errorRate)
+			}
+			if got.Latency == nil || got.Latency.Count != tt.latencies {
+				t.Errorf("Latency = %+v, want %d merged samples", got.Latency, tt.latencies)
+			}
+			if got.Iterations.Count != len(tt.runs) || got.Iterations.Warmup != 1 {
+				t.Errorf("Iterations = %d (warmup %d), want %d (warmup 1)", got.Iterations.Count, got.Iterations.Warmup, len(tt.runs))
+			}
+			if n := len(got.Iterations.Throughput.Samples); n != len(tt.runs) {
+				t.Errorf("%d throughput samples, want %d", n, len(tt.runs))
+			}
+		})
+	}
+}
+
+func TestAggregateResultsEmpty(t *testing.T) {
+	if got := AggregateResults(nil, 0); got != nil {
+		t.Fatalf("AggregateResults(nil) = %+v, want nil", got)
+	}
+}
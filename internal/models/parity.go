@@ -0,0 +1,56 @@
+package models
+
+import "sort"
+
+// ParityGap is an operation that produced results on only some of the engines
+// that ran, so its numbers cannot be compared across all of them.
+type ParityGap struct {
+	Operation string   `json:"operation"`
+	Ran       []string `json:"ran"`
+	Missing   []string `json:"missing"`
+}
+
+// EngineSpecific marks, in Metadata, a result of an operation that only some
+// engines offer by design, such as an extra bulk-load strategy. Such results
+// are left out of parity checks.
+const EngineSpecific = "engine_specific"
+
+// FindParityGaps lists, in order of first appearance, the operations missing
+// from at least one database that produced results.
+func FindParityGaps(results []BenchmarkResult) []ParityGap {
+	databases := make([]string, 0)
+	seenDatabase := make(map[string]bool)
+	operations := make([]string, 0)
+	ran := make(map[string]map[string]bool)
+	for _, result := range results {
+		if specific, _ := result.Metadata[EngineSpecific].(bool); specific {
+			continue
+		}
+		if !seenDatabase[result.Database] {
+			seenDatabase[result.Database] = true
+			databases = append(databases, result.Database)
+		}
+		if ran[result.Operation] == nil {
+			ran[result.Operation] = make(map[string]bool)
+			operations = append(operations, result.Operation)
+		}
+		ran[result.Operation][result.Database] = true
+	}
+	sort.Strings(databases)
+
+	gaps := make([]ParityGap, 0)
+	for _, operation := range operations {
+		gap := ParityGap{Operation: operation}
+		for _, database := range databases {
+			if ran[operation][database] {
+				gap.Ran = append(gap.Ran, database)
+			} else {
+				gap.Missing = append(gap.Missing, database)
+			}
+		}
+		if len(gap.Missing) > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func ran(operation, database string) BenchmarkResult {
+	return BenchmarkResult{Operation: operation, Database: database}
+}
+
+func TestFindParityGaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []BenchmarkResult
+		want    []ParityGap
+	}{
+		{
+			name: "full parity",
+			results: []BenchmarkResult{
+				ran("Bulk Insert", "postgres"), ran("Bulk Insert", "surrealdb"),
+			},
+			want: []ParityGap{},
+		},
+		{
+			name: "missing on one engine",
+			results: []BenchmarkResult{
+				ran("Bulk Insert", "surrealdb"), ran("Bulk Insert", "postgres"),
+				ran("Indexed Query", "postgres"),
+				ran("Full-Text Search", "postgres"), ran("Full-Text Search", "mysql"),
+				ran("Bulk Insert", "mysql"),
+			},
+			want: []ParityGap{
+				{Operation: "Indexed Query", Ran: []string{"postgres"}, Missing: []string{"mysql", "surrealdb"}},
+				{Operation: "Full-Text Search", Ran: []string{"mysql", "postgres"}, Missing: []string{"surrealdb"}},
+			},
+		},
+		{
+			// Extra bulk-load strategies exist on one engine by design.
+			name: "engine specific",
+			results: []BenchmarkResult{
+				ran("Bulk Insert", "postgres"), ran("Bulk Insert", "surrealdb"),
+				{Operation: "Bulk Insert (copy)", Database: "postgres", Metadata: map[string]any{EngineSpecific: true}},
+			},
+			want: []ParityGap{},
+		},
+		{
+			name:    "single engine",
+			results: []BenchmarkResult{ran("Bulk Insert", "postgres"), ran("Indexed Query", "postgres")},
+			want:    []ParityGap{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindParityGaps(tt.results); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindParityGaps = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/nadmax/dbcompare/internal/metrics"
+)
+
+type ScalingPoint struct {
+	Concurrency int                   `json:"concurrency"`
+	Throughput  float64               `json:"throughput"`
+	Speedup     float64               `json:"speedup"`
+	Efficiency  float64               `json:"efficiency"`
+	Latency     *metrics.LatencyStats `json:"latency,omitempty"`
+}
+
+type ScalingCurve struct {
+	Database  string         `json:"database"`
+	Operation string         `json:"operation"`
+	Points    []ScalingPoint `json:"points"`
+}
+
+// BuildScalingCurves groups results carrying "operation" and "concurrency"
+// metadata into one curve per database and operation. Speedup and efficiency
+// are relative to the lowest concurrency level measured.
+func BuildScalingCurves(results []BenchmarkResult) []ScalingCurve {
+	type key struct{ database, operation string }
+
+	curves := make(map[key]*ScalingCurve)
+	order := make([]key, 0)
+	for _, result := range results {
+		operation, ok := result.Metadata["operation"].(string)
+		if !ok {
+			continue
+		}
+		concurrency, ok := result.Metadata["concurrency"].(int)
+		if !ok {
+			continue
+		}
+
+		k := key{result.Database, operation}
+		curve, exists := curves[k]
+		if !exists {
+			curve = &ScalingCurve{Database: result.Database, Operation: operation}
+			curves[k] = curve
+			order = append(order, k)
+		}
+		curve.Points = append(curve.Points, ScalingPoint{
+			Concurrency: concurrency,
+			Throughput:  result.Throughput,
+			Latency:     result.Latency,
+		})
+	}
+
+	scaling := make([]ScalingCurve, 0, len(order))
+	for _, k := range order {
+		curve := curves[k]
+		if len(curve.Points) < 2 {
+			continue
+		}
+
+		sort.Slice(curve.Points, func(i, j int) bool {
+			return curve.Points[i].Concurrency < curve.Points[j].Concurrency
+		})
+		base := curve.Points[0]
+		for i := range curve.Points {
+			point := &curve.Points[i]
+			if base.Throughput > 0 {
+				point.Speedup = point.Throughput / base.Throughput
+			}
+			point.Efficiency = point.Speedup / (float64(point.Concurrency) / float64(base.Concurrency))
+		}
+		scaling = append(scaling, *curve)
+	}
+
+	return scaling
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func level(database, operation string, concurrency int, throughput float64) BenchmarkResult {
+	return BenchmarkResult{
+		Database:   database,
+		Throughput: throughput,
+		Metadata:   map[string]any{"operation": operation, "concurrency": concurrency},
+	}
+}
+
+func TestBuildScalingCurves(t *testing.T) {
+	results := []BenchmarkResult{
+		level("postgres", "Concurrent Reads", 4, 3000),
+		level("postgres", "Concurrent Reads", 1, 1000),
+		level("postgres", "Concurrent Reads", 2, 1800),
+		level("surrealdb", "Concurrent Reads", 1, 500),
+		level("surrealdb", "Concurrent Reads", 8, 2000),
+		// A single level makes no curve.
+		level("postgres", "Concurrent Writes", 1, 400),
+		// Results without sweep metadata are ignored.
+		{Database: "postgres", Operation: "Bulk Insert", Throughput: 9000},
+	}
+
+	type point struct {
+		concurrency         int
+		speedup, efficiency float64
+	}
+	want := []struct {
+		database string
+		points   []point
+	}{
+		{"postgres", []point{{1, 1, 1}, {2, 1.8, 0.9}, {4, 3, 0.75}}},
+		{"surrealdb", []point{{1, 1, 1}, {8, 4, 0.5}}},
+	}
+
+	curves := BuildScalingCurves(results)
+	if len(curves) != len(want) {
+		t.Fatalf("got %d curves, want %d: %+v", len(curves), len(want), curves)
+	}
+	for i, w := range want {
+		curve := curves[i]
+		if curve.Database != w.database || curve.Operation != "Concurrent Reads" {
+			t.Fatalf("curve %d is %s/%s, want %s/Concurrent Reads", i, curve.Database, curve.Operation, w.database)
+		}
+		if len(curve.Points) != len(w.points) {
+			t.Fatalf("%s: got %d points, want %d", w.database, len(curve.Points), len(w.points))
+		}
+		for j, p := range w.points {
+			got := curve.Points[j]
+			if got.Concurrency != p.concurrency ||
+				math.Abs(got.Speedup-p.speedup) > 1e-9 ||
+				math.Abs(got.Efficiency-p.efficiency) > 1e-9 {
+				t.Errorf("%s point %d = {%d %g %g}, want %+v", w.database, j, got.Concurrency, got.Speedup, got.Efficiency, p)
+			}
+		}
+	}
+}
@@ -2,16 +2,26 @@ package reporter
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/nadmax/dbcompare/internal/metrics"
 	"github.com/nadmax/dbcompare/internal/models"
+	"github.com/nadmax/dbcompare/internal/stats"
 )
 
-type ConsoleReporter struct{}
+type ConsoleReporter struct {
+	significanceTest  string
+	significanceLevel float64
+}
 
-func NewConsoleReporter() *ConsoleReporter {
-	return &ConsoleReporter{}
+func NewConsoleReporter(significanceTest string, significanceLevel float64) *ConsoleReporter {
+	return &ConsoleReporter{
+		significanceTest:  significanceTest,
+		significanceLevel: significanceLevel,
+	}
 }
 
 func (c *ConsoleReporter) Name() string {
@@ -24,7 +34,11 @@ func (c *ConsoleReporter) Generate(suite *models.BenchmarkSuite) error {
 	fmt.Println(strings.Repeat("=", 100))
 	fmt.Printf("\nTotal Duration: %v\n", suite.Duration)
 	fmt.Printf("Start Time: %s\n", suite.StartTime.Format("2006-01-02 15:04:05"))
-	fmt.Printf("End Time: %s\n\n", suite.EndTime.Format("2006-01-02 15:04:05"))
+	fmt.Printf("End Time: %s\n", suite.EndTime.Format("2006-01-02 15:04:05"))
+	if seed, ok := suite.Config["seed"]; ok {
+		fmt.Printf("Seed: %v\n", seed)
+	}
+	fmt.Println()
 
 	dbResults := make(map[string][]models.BenchmarkResult)
 	for _, result := range suite.Results {
@@ -37,6 +51,14 @@ func (c *ConsoleReporter) Generate(suite *models.BenchmarkSuite) error {
 
 	c.printComparisonTable(suite.Results)
 
+	c.printOfferedLoad(suite.Results)
+
+	c.printTPCC(suite.Results)
+
+	c.printScaling(suite.Scaling)
+
+	c.printParity(suite.Parity)
+
 	c.printPerformanceSummary(suite.Results)
 
 	fmt.Println(strings.Repeat("=", 100))
@@ -66,9 +88,198 @@ func (c *ConsoleReporter) printDatabaseResults(database string, results []models
 			errorPercent,
 		)
 	}
+
+	c.printIterationTable(results)
+	c.printLatencyTable(results)
+	c.printTimeline(results)
+	c.printBreakdown(results)
+	c.printConsistency(results)
 	fmt.Printf("└%s\n", strings.Repeat("─", 97))
 }
 
+// printConsistency lists the invariant checks run after transactional suites,
+// such as TPC-B balance conservation.
+func (c *ConsoleReporter) printConsistency(results []models.BenchmarkResult) {
+	header := false
+	for _, result := range results {
+		conserved, ok := result.Metadata["balance_conserved"].(bool)
+		if !ok {
+			continue
+		}
+
+		if !header {
+			fmt.Printf("│\n")
+			fmt.Printf("│ %-30s %s\n", "Consistency check", "Result")
+			fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+			header = true
+		}
+
+		status := "✓ balance conserved"
+		if !conserved {
+			status = "⚠ balance NOT conserved"
+		}
+		fmt.Printf("│ %-30s %s\n", result.Operation, status)
+	}
+}
+
+func (c *ConsoleReporter) printBreakdown(results []models.BenchmarkResult) {
+	header := false
+	for _, result := range results {
+		if len(result.Breakdown) == 0 {
+			continue
+		}
+
+		if !header {
+			fmt.Printf("│\n")
+			fmt.Printf("│ %-30s %7s %10s %13s %10s %10s %8s\n", "Operation breakdown", "Share", "Ops", "Throughput", "Mean", "Max", "Errors")
+			fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+			header = true
+		}
+
+		fmt.Printf("│ %s\n", result.Operation)
+		for _, b := range result.Breakdown {
+			if b.Count == 0 {
+				continue
+			}
+			mean, maximum := "n/a", "n/a"
+			if b.Latency != nil {
+				mean = roundLatency(b.Latency.Mean).String()
+				maximum = roundLatency(b.Latency.Max).String()
+			}
+			fmt.Printf("│   %-28s %6.1f%% %10d %11.0f/s %10s %10s %7.2f%%\n",
+				b.Operation,
+				b.Share*100,
+				b.Count,
+				b.Throughput,
+				mean,
+				maximum,
+				b.ErrorRate*100,
+			)
+		}
+	}
+}
+
+func (c *ConsoleReporter) printIterationTable(results []models.BenchmarkResult) {
+	header := false
+	for _, result := range results {
+		it := result.Iterations
+		if it == nil || it.Count < 2 {
+			continue
+		}
+
+		if !header {
+			fmt.Printf("│\n")
+			fmt.Printf("│ %-30s %6s %12s %12s %10s %25s\n", "Throughput (ops/s)", "Runs", "Mean", "Median", "StdDev", "95% CI")
+			fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+			header = true
+		}
+
+		fmt.Printf("│ %-30s %6d %12.0f %12.0f %10.0f %25s\n",
+			result.Operation,
+			it.Count,
+			it.Throughput.Mean,
+			it.Throughput.Median,
+			it.Throughput.StdDev,
+			fmt.Sprintf("[%.0f, %.0f]", it.Throughput.CI95Low, it.Throughput.CI95High),
+		)
+	}
+}
+
+func (c *ConsoleReporter) printLatencyTable(results []models.BenchmarkResult) {
+	var percentiles []metrics.Percentile
+	for _, result := range results {
+		if result.Latency != nil {
+			percentiles = result.Latency.Percentiles
+			break
+		}
+	}
+	if percentiles == nil {
+		return
+	}
+
+	fmt.Printf("│\n")
+	fmt.Printf("│ %-30s %10s %10s %10s", "Latency", "Min", "Mean", "StdDev")
+	for _, p := range percentiles {
+		fmt.Printf(" %10s", metrics.PercentileLabel(p.Percentile))
+	}
+	fmt.Printf(" %10s\n", "Max")
+	fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+
+	for _, result := range results {
+		if result.Latency == nil {
+			continue
+		}
+
+		fmt.Printf("│ %-30s %10v %10v %10v",
+			result.Operation,
+			roundLatency(result.Latency.Min),
+			roundLatency(result.Latency.Mean),
+			roundLatency(result.Latency.StdDev),
+		)
+		for _, p := range result.Latency.Percentiles {
+			fmt.Printf(" %10v", roundLatency(p.Value))
+		}
+		fmt.Printf(" %10v\n", roundLatency(result.Latency.Max))
+	}
+}
+
+func (c *ConsoleReporter) printTimeline(results []models.BenchmarkResult) {
+	header := false
+	for _, result := range results {
+		if len(result.Timeline) == 0 {
+			continue
+		}
+
+		if !header {
+			fmt.Printf("│\n")
+			fmt.Printf("│ %-30s %10s %10s %10s  %s\n", "Throughput timeline (ops/s)", "Min", "Avg", "Max", "Per second")
+			fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+			header = true
+		}
+
+		lo, hi, sum := math.Inf(1), 0.0, 0.0
+		for _, sample := range result.Timeline {
+			lo = min(lo, sample.Throughput)
+			hi = max(hi, sample.Throughput)
+			sum += sample.Throughput
+		}
+
+		fmt.Printf("│ %-30s %10.0f %10.0f %10.0f  %s\n",
+			result.Operation,
+			lo,
+			sum/float64(len(result.Timeline)),
+			hi,
+			sparkline(result.Timeline, lo, hi),
+		)
+	}
+}
+
+func sparkline(samples []metrics.TimelineSample, lo, hi float64) string {
+	const bars = "▁▂▃▄▅▆▇█"
+	levels := []rune(bars)
+
+	var sb strings.Builder
+	for _, sample := range samples {
+		level := len(levels) - 1
+		if hi > lo {
+			level = int((sample.Throughput - lo) / (hi - lo) * float64(len(levels)-1))
+		}
+		sb.WriteRune(levels[level])
+	}
+	return sb.String()
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
+
 func (c *ConsoleReporter) printComparisonTable(results []models.BenchmarkResult) {
 	opResults := make(map[string][]models.BenchmarkResult)
 	for _, result := range results {
@@ -76,6 +287,7 @@ func (c *ConsoleReporter) printComparisonTable(results []models.BenchmarkResult)
 	}
 
 	fmt.Println("\n┌─ OPERATION COMPARISON")
+	fmt.Printf("│ p-values from %s test against each tier's fastest engine, α = %.2f\n", c.significanceTest, c.significanceLevel)
 	fmt.Println("│")
 
 	for operation, opRes := range opResults {
@@ -90,27 +302,41 @@ func (c *ConsoleReporter) printComparisonTable(results []models.BenchmarkResult)
 			return opRes[i].Throughput > opRes[j].Throughput
 		})
 
+		tiers, pValues := c.rankTiers(opRes)
+		tierSizes := make(map[int]int)
+		for _, tier := range tiers {
+			tierSizes[tier]++
+		}
+
 		fastest := opRes[0].Throughput
 		for i, result := range opRes {
 			rank := fmt.Sprintf("#%d", i+1)
 			percentDiff := ((result.Throughput - fastest) / fastest) * 100
 
-			indicator := "🥇"
-			if i == 1 {
-				indicator = "🥈"
-			} else if i == 2 {
-				indicator = "🥉"
-			} else if i > 2 {
-				indicator = "  "
+			indicator := "   "
+			if tierSizes[tiers[i]] > 1 {
+				indicator = "tie"
+			} else if tiers[i] == 0 {
+				indicator = "🥇 "
+			} else if tiers[i] == 1 {
+				indicator = "🥈 "
+			} else if tiers[i] == 2 {
+				indicator = "🥉 "
 			}
 
-			fmt.Printf("│   %s %-3s %-15s %12.0f/s (%+6.1f%%) %12v\n",
+			pValue := ""
+			if i > 0 && !math.IsNaN(pValues[i]) {
+				pValue = fmt.Sprintf("p=%.4f", pValues[i])
+			}
+
+			fmt.Printf("│   %s %-3s %-15s %12.0f/s (%+6.1f%%) %12v %10s\n",
 				indicator,
 				rank,
 				result.Database,
 				result.Throughput,
 				percentDiff,
 				result.Duration,
+				pValue,
 			)
 		}
 		fmt.Printf("│\n")
@@ -118,6 +344,217 @@ func (c *ConsoleReporter) printComparisonTable(results []models.BenchmarkResult)
 	fmt.Printf("└%s\n", strings.Repeat("─", 97))
 }
 
+// scalingGain is the minimum throughput gain over the previous concurrency
+// level for an engine to count as still scaling.
+const scalingGain = 1.05
+
+func (c *ConsoleReporter) printScaling(curves []models.ScalingCurve) {
+	if len(curves) == 0 {
+		return
+	}
+
+	fmt.Println("\n┌─ CONCURRENCY SCALING")
+	fmt.Println("│")
+
+	for i, curve := range curves {
+		if i > 0 {
+			fmt.Printf("│\n")
+		}
+		fmt.Printf("│ %s / %s\n", curve.Database, curve.Operation)
+		fmt.Printf("│   %8s %12s %9s %10s %10s  %s\n", "Workers", "Throughput", "Speedup", "Efficiency", "Mean", "Curve")
+		fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+
+		peak := 0.0
+		for _, point := range curve.Points {
+			peak = max(peak, point.Throughput)
+		}
+
+		stopped := false
+		for j, point := range curve.Points {
+			mean := "n/a"
+			if point.Latency != nil {
+				mean = roundLatency(point.Latency.Mean).String()
+			}
+
+			width := 0
+			if peak > 0 {
+				width = int(point.Throughput / peak * 30)
+			}
+
+			marker := ""
+			if j > 0 && !stopped && point.Throughput < curve.Points[j-1].Throughput*scalingGain {
+				marker = " ◀ stops scaling"
+				stopped = true
+			}
+
+			fmt.Printf("│   %8d %10.0f/s %8.2fx %9.0f%% %10s  %s%s\n",
+				point.Concurrency,
+				point.Throughput,
+				point.Speedup,
+				point.Efficiency*100,
+				mean,
+				strings.Repeat("█", width),
+				marker,
+			)
+		}
+	}
+	fmt.Printf("└%s\n", strings.Repeat("─", 97))
+}
+
+func (c *ConsoleReporter) printTPCC(results []models.BenchmarkResult) {
+	tpcc := make([]models.BenchmarkResult, 0)
+	for _, result := range results {
+		if _, ok := result.Metadata["tpmC"].(float64); ok {
+			tpcc = append(tpcc, result)
+		}
+	}
+	if len(tpcc) == 0 {
+		return
+	}
+
+	sort.SliceStable(tpcc, func(i, j int) bool {
+		return tpcc[i].Metadata["tpmC"].(float64) > tpcc[j].Metadata["tpmC"].(float64)
+	})
+
+	fmt.Println("\n┌─ TPC-C (tpmC = New-Order transactions per minute)")
+	fmt.Println("│")
+	fmt.Printf("│ %-20s %-36s %12s %14s %10s\n", "Database", "Run", "tpmC", "Total tpm", "Errors")
+	fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+	for _, result := range tpcc {
+		fmt.Printf("│ %-20s %-36s %12.0f %14.0f %9.2f%%\n",
+			result.Database,
+			result.Operation,
+			result.Metadata["tpmC"].(float64),
+			result.Throughput*60,
+			result.ErrorRate*100,
+		)
+	}
+	fmt.Printf("└%s\n", strings.Repeat("─", 97))
+}
+
+// saturationRatio is the achieved/offered rate below which an open-loop run is
+// considered saturated.
+const saturationRatio = 0.95
+
+func (c *ConsoleReporter) printOfferedLoad(results []models.BenchmarkResult) {
+	openLoop := make([]models.BenchmarkResult, 0)
+	for _, result := range results {
+		if _, ok := offeredRate(result); ok {
+			openLoop = append(openLoop, result)
+		}
+	}
+	if len(openLoop) == 0 {
+		return
+	}
+
+	sort.SliceStable(openLoop, func(i, j int) bool {
+		a, b := openLoop[i], openLoop[j]
+		if a.Database != b.Database {
+			return a.Database < b.Database
+		}
+		if opA, opB := fmt.Sprint(a.Metadata["operation"]), fmt.Sprint(b.Metadata["operation"]); opA != opB {
+			return opA < opB
+		}
+		rateA, _ := offeredRate(a)
+		rateB, _ := offeredRate(b)
+		return rateA < rateB
+	})
+
+	fmt.Println("\n┌─ LATENCY VS OFFERED LOAD (open loop, latency from intended start)")
+	fmt.Println("│")
+
+	lastGroup := ""
+	for _, result := range openLoop {
+		group := fmt.Sprintf("%s / %v", result.Database, result.Metadata["operation"])
+		if group != lastGroup {
+			if lastGroup != "" {
+				fmt.Printf("│\n")
+			}
+			fmt.Printf("│ %s\n", group)
+			fmt.Printf("│   %10s %10s", "Offered/s", "Achieved/s")
+			if result.Latency != nil {
+				for _, p := range result.Latency.Percentiles {
+					fmt.Printf(" %10s", metrics.PercentileLabel(p.Percentile))
+				}
+			}
+			fmt.Printf(" %10s  %s\n", "Max", "Status")
+			fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+			lastGroup = group
+		}
+
+		rate, _ := offeredRate(result)
+		fmt.Printf("│   %10.0f %10.0f", rate, result.Throughput)
+		if result.Latency != nil {
+			for _, p := range result.Latency.Percentiles {
+				fmt.Printf(" %10v", roundLatency(p.Value))
+			}
+			fmt.Printf(" %10v", roundLatency(result.Latency.Max))
+		}
+
+		status := "✓"
+		if result.Throughput < rate*saturationRatio {
+			status = "⚠ saturated"
+		}
+		fmt.Printf("  %s\n", status)
+	}
+	fmt.Printf("└%s\n", strings.Repeat("─", 97))
+}
+
+func offeredRate(result models.BenchmarkResult) (float64, bool) {
+	rate, ok := result.Metadata["offered_rate"].(float64)
+	return rate, ok
+}
+
+// rankTiers groups results sorted by throughput into tiers: a result joins the
+// current tier unless the significance test against the tier's leader, its
+// fastest result, rejects equality. Comparing with the leader rather than the
+// neighbour keeps A≈B and B≈C from chaining A and C together when A≠C.
+// pValues[i] holds the p-value of result i against the leader it was compared
+// with, or NaN when there were not enough samples to test, in which case the
+// result starts a tier of its own.
+func (c *ConsoleReporter) rankTiers(sorted []models.BenchmarkResult) ([]int, []float64) {
+	tiers := make([]int, len(sorted))
+	pValues := make([]float64, len(sorted))
+	pValues[0] = math.NaN()
+
+	leader := 0
+	for i := 1; i < len(sorted); i++ {
+		pValues[i] = math.NaN()
+		tiers[i] = tiers[i-1] + 1
+
+		lead, cur := sorted[leader].Iterations, sorted[i].Iterations
+		if lead != nil && cur != nil {
+			if p, ok := stats.Compare(c.significanceTest, lead.Throughput.Samples, cur.Throughput.Samples); ok {
+				pValues[i] = p
+				if p >= c.significanceLevel {
+					tiers[i] = tiers[i-1]
+					continue
+				}
+			}
+		}
+		leader = i
+	}
+
+	return tiers, pValues
+}
+
+// printParity warns about operations missing on some engines. They only count
+// towards the ranking of the engines that ran them.
+func (c *ConsoleReporter) printParity(gaps []models.ParityGap) {
+	if len(gaps) == 0 {
+		return
+	}
+
+	fmt.Println("\n┌─ ⚠ PARITY WARNINGS (operations not run on every engine)")
+	fmt.Println("│")
+	fmt.Printf("│ %-34s %-30s %s\n", "Operation", "Ran on", "Missing on")
+	fmt.Printf("│ %s\n", strings.Repeat("─", 95))
+	for _, gap := range gaps {
+		fmt.Printf("│ %-34s %-30s %s\n", gap.Operation, strings.Join(gap.Ran, ", "), strings.Join(gap.Missing, ", "))
+	}
+	fmt.Printf("└%s\n", strings.Repeat("─", 97))
+}
+
 func (c *ConsoleReporter) printPerformanceSummary(results []models.BenchmarkResult) {
 	dbScores := make(map[string]int)
 
@@ -135,14 +572,13 @@ func (c *ConsoleReporter) printPerformanceSummary(results []models.BenchmarkResu
 			return opRes[i].Throughput > opRes[j].Throughput
 		})
 
-		if len(opRes) > 0 {
-			dbScores[opRes[0].Database] += 3
-		}
-		if len(opRes) > 1 {
-			dbScores[opRes[1].Database] += 2
-		}
-		if len(opRes) > 2 {
-			dbScores[opRes[2].Database] += 1
+		// The first three tiers score 3, 2 and 1 points; engines that are
+		// statistically tied share the points of their tier.
+		tiers, _ := c.rankTiers(opRes)
+		for i, result := range opRes {
+			if tiers[i] < 3 {
+				dbScores[result.Database] += 3 - tiers[i]
+			}
 		}
 	}
 
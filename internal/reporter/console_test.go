@@ -0,0 +1,56 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/nadmax/dbcompare/internal/models"
+	"github.com/nadmax/dbcompare/internal/stats"
+)
+
+func sampled(database string, samples ...float64) models.BenchmarkResult {
+	return models.BenchmarkResult{
+		Operation:  "Random Read",
+		Database:   database,
+		Throughput: stats.Mean(samples),
+		Iterations: &models.IterationStats{Throughput: stats.Summary{Samples: samples}},
+	}
+}
+
+func TestRankTiersComparesWithLeader(t *testing.T) {
+	c := NewConsoleReporter(stats.TestWelch, 0.05)
+	a := sampled("A", 1000, 1002, 998, 1001, 999)
+	b := sampled("B", 990, 1030, 950, 1010, 970)
+	cc := sampled("C", 961, 959, 960, 962, 958)
+
+	// B is tied with both A and C, but A and C differ: C must not be chained
+	// into A's tier through B.
+	for _, pair := range [][2]models.BenchmarkResult{{a, b}, {b, cc}} {
+		if p, _ := stats.Compare(stats.TestWelch, pair[0].Iterations.Throughput.Samples, pair[1].Iterations.Throughput.Samples); p < 0.05 {
+			t.Fatalf("%s vs %s: p = %.4f, want a tie", pair[0].Database, pair[1].Database, p)
+		}
+	}
+
+	tiers, pValues := c.rankTiers([]models.BenchmarkResult{a, b, cc})
+	want := []int{0, 0, 1}
+	for i := range want {
+		if tiers[i] != want[i] {
+			t.Fatalf("tiers = %v, want %v (p-values %v)", tiers, want, pValues)
+		}
+	}
+}
+
+func TestRankTiersWithoutSamples(t *testing.T) {
+	c := NewConsoleReporter(stats.TestWelch, 0.05)
+	results := []models.BenchmarkResult{
+		{Database: "A", Throughput: 300},
+		{Database: "B", Throughput: 200},
+		{Database: "C", Throughput: 100},
+	}
+
+	tiers, _ := c.rankTiers(results)
+	for i, tier := range tiers {
+		if tier != i {
+			t.Fatalf("tiers = %v, want one tier per result", tiers)
+		}
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/nadmax/dbcompare/internal/metrics"
 	"github.com/nadmax/dbcompare/internal/models"
 )
 
@@ -46,6 +49,25 @@ func (c *CSVReporter) Generate(suite *models.BenchmarkSuite) error {
 		"Error Rate (%)",
 		"Start Time",
 		"End Time",
+		"Iterations",
+		"Warmup Iterations",
+		"Throughput Mean (ops/s)",
+		"Throughput Median (ops/s)",
+		"Throughput StdDev (ops/s)",
+		"Throughput CI95 Low (ops/s)",
+		"Throughput CI95 High (ops/s)",
+		"Concurrency",
+		"Offered Rate (ops/s)",
+		"tpmC",
+		"Timeline (ops/s per second)",
+		"Latency Min (ms)",
+		"Latency Mean (ms)",
+		"Latency Max (ms)",
+		"Latency StdDev (ms)",
+	}
+	percentiles := latencyPercentiles(suite.Results)
+	for _, p := range percentiles {
+		header = append(header, fmt.Sprintf("Latency %s (ms)", metrics.PercentileLabel(p)))
 	}
 	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
@@ -63,11 +85,240 @@ func (c *CSVReporter) Generate(suite *models.BenchmarkSuite) error {
 			result.StartTime.Format("2006-01-02 15:04:05"),
 			result.EndTime.Format("2006-01-02 15:04:05"),
 		}
+		row = append(row, iterationColumns(result.Iterations)...)
+		row = append(row, concurrencyColumn(result))
+		row = append(row, offeredRateColumn(result))
+		row = append(row, tpmCColumn(result))
+		row = append(row, timelineColumn(result.Timeline))
+		row = append(row, latencyColumns(result.Latency, len(percentiles))...)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write row: %w", err)
 		}
 	}
 
 	fmt.Printf("✓ CSV report saved to: %s\n", c.filename)
+
+	if len(suite.Scaling) > 0 {
+		if err := c.generateScaling(suite.Scaling); err != nil {
+			return err
+		}
+	}
+	return c.generateBreakdown(suite.Results, percentiles)
+}
+
+// generateBreakdown writes the per-operation breakdown of mixed workloads next
+// to the main report, one row per database, workload and operation type.
+func (c *CSVReporter) generateBreakdown(results []models.BenchmarkResult, percentiles []float64) error {
+	mixed := make([]models.BenchmarkResult, 0)
+	for _, result := range results {
+		if len(result.Breakdown) > 0 {
+			mixed = append(mixed, result)
+		}
+	}
+	if len(mixed) == 0 {
+		return nil
+	}
+
+	filename := strings.TrimSuffix(c.filename, ".csv") + "_breakdown.csv"
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create breakdown CSV file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			fmt.Printf("Warning: failed to close file: %v\n", err)
+		}
+	}()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{
+		"Database",
+		"Workload",
+		"Operation",
+		"Count",
+		"Share (%)",
+		"Throughput (ops/s)",
+		"Errors",
+		"Error Rate (%)",
+		"Latency Min (ms)",
+		"Latency Mean (ms)",
+		"Latency Max (ms)",
+		"Latency StdDev (ms)",
+	}
+	for _, p := range percentiles {
+		header = append(header, fmt.Sprintf("Latency %s (ms)", metrics.PercentileLabel(p)))
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, result := range mixed {
+		for _, b := range result.Breakdown {
+			row := []string{
+				result.Database,
+				result.Operation,
+				b.Operation,
+				fmt.Sprintf("%d", b.Count),
+				fmt.Sprintf("%.2f", b.Share*100),
+				fmt.Sprintf("%.2f", b.Throughput),
+				fmt.Sprintf("%d", b.ErrorCount),
+				fmt.Sprintf("%.2f", b.ErrorRate*100),
+			}
+			row = append(row, latencyColumns(b.Latency, len(percentiles))...)
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("failed to write row: %w", err)
+			}
+		}
+	}
+
+	fmt.Printf("✓ CSV breakdown report saved to: %s\n", filename)
 	return nil
 }
+
+// generateScaling writes the concurrency sweep as a long-format table next to
+// the main report, one row per database, operation and concurrency level.
+func (c *CSVReporter) generateScaling(curves []models.ScalingCurve) error {
+	filename := strings.TrimSuffix(c.filename, ".csv") + "_scaling.csv"
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create scaling CSV file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			fmt.Printf("Warning: failed to close file: %v\n", err)
+		}
+	}()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{
+		"Database",
+		"Operation",
+		"Concurrency",
+		"Throughput (ops/s)",
+		"Speedup",
+		"Efficiency (%)",
+		"Latency Mean (ms)",
+		"Latency Max (ms)",
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, curve := range curves {
+		for _, point := range curve.Points {
+			mean, maximum := "", ""
+			if point.Latency != nil {
+				mean = formatMillis(point.Latency.Mean)
+				maximum = formatMillis(point.Latency.Max)
+			}
+			row := []string{
+				curve.Database,
+				curve.Operation,
+				fmt.Sprintf("%d", point.Concurrency),
+				fmt.Sprintf("%.2f", point.Throughput),
+				fmt.Sprintf("%.3f", point.Speedup),
+				fmt.Sprintf("%.1f", point.Efficiency*100),
+				mean,
+				maximum,
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("failed to write row: %w", err)
+			}
+		}
+	}
+
+	fmt.Printf("✓ CSV scaling report saved to: %s\n", filename)
+	return nil
+}
+
+func latencyPercentiles(results []models.BenchmarkResult) []float64 {
+	for _, result := range results {
+		if result.Latency == nil {
+			continue
+		}
+		percentiles := make([]float64, 0, len(result.Latency.Percentiles))
+		for _, p := range result.Latency.Percentiles {
+			percentiles = append(percentiles, p.Percentile)
+		}
+		return percentiles
+	}
+	return nil
+}
+
+func iterationColumns(it *models.IterationStats) []string {
+	if it == nil {
+		return []string{"1", "0", "", "", "", "", ""}
+	}
+
+	return []string{
+		fmt.Sprintf("%d", it.Count),
+		fmt.Sprintf("%d", it.Warmup),
+		fmt.Sprintf("%.2f", it.Throughput.Mean),
+		fmt.Sprintf("%.2f", it.Throughput.Median),
+		fmt.Sprintf("%.2f", it.Throughput.StdDev),
+		fmt.Sprintf("%.2f", it.Throughput.CI95Low),
+		fmt.Sprintf("%.2f", it.Throughput.CI95High),
+	}
+}
+
+func concurrencyColumn(result models.BenchmarkResult) string {
+	if concurrency, ok := result.Metadata["concurrency"].(int); ok {
+		return fmt.Sprintf("%d", concurrency)
+	}
+	return ""
+}
+
+func tpmCColumn(result models.BenchmarkResult) string {
+	if tpmC, ok := result.Metadata["tpmC"].(float64); ok {
+		return fmt.Sprintf("%.0f", tpmC)
+	}
+	return ""
+}
+
+func offeredRateColumn(result models.BenchmarkResult) string {
+	if rate, ok := result.Metadata["offered_rate"].(float64); ok {
+		return fmt.Sprintf("%.2f", rate)
+	}
+	return ""
+}
+
+func timelineColumn(timeline []metrics.TimelineSample) string {
+	values := make([]string, 0, len(timeline))
+	for _, sample := range timeline {
+		values = append(values, fmt.Sprintf("%.0f", sample.Throughput))
+	}
+	return strings.Join(values, ";")
+}
+
+func latencyColumns(latency *metrics.LatencyStats, percentiles int) []string {
+	columns := make([]string, 0, 4+percentiles)
+	if latency == nil {
+		for range 4 + percentiles {
+			columns = append(columns, "")
+		}
+		return columns
+	}
+
+	columns = append(columns,
+		formatMillis(latency.Min),
+		formatMillis(latency.Mean),
+		formatMillis(latency.Max),
+		formatMillis(latency.StdDev),
+	)
+	for i := range percentiles {
+		if i < len(latency.Percentiles) {
+			columns = append(columns, formatMillis(latency.Percentiles[i].Value))
+		} else {
+			columns = append(columns, "")
+		}
+	}
+	return columns
+}
+
+func formatMillis(d time.Duration) string {
+	return fmt.Sprintf("%.3f", float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStudentTCDF(t *testing.T) {
+	// Closed forms for df 1 and 2; the rest were integrated numerically from
+	// the t density.
+	tests := []struct {
+		t, df, want float64
+	}{
+		{0, 5, 0.5},
+		{1, 1, 0.75},
+		{-1, 1, 0.25},
+		{1, 2, 0.5 + 1/(2*math.Sqrt(3))},
+		{2.228139, 10, 0.975},
+		{-2.5, 7.3, 0.019825117},
+		{1.5, 24.6, 0.926829831},
+	}
+
+	for _, tt := range tests {
+		if got := StudentTCDF(tt.t, tt.df); math.Abs(got-tt.want) > 1e-8 {
+			t.Errorf("StudentTCDF(%g, %g) = %.10f, want %.10f", tt.t, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	// Critical values from standard t tables.
+	tests := []struct {
+		p, df, want float64
+	}{
+		{0.975, 1, 12.706205},
+		{0.975, 2, 4.302653},
+		{0.975, 5, 2.570582},
+		{0.975, 10, 2.228139},
+		{0.975, 30, 2.042272},
+		{0.975, 1e6, 1.959966},
+		{0.95, 10, 1.812461},
+		{0.995, 5, 4.032143},
+		{0.5, 3, 0},
+		{0.025, 5, -2.570582},
+	}
+
+	for _, tt := range tests {
+		if got := StudentTQuantile(tt.p, tt.df); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("StudentTQuantile(%g, %g) = %.7f, want %.7f", tt.p, tt.df, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	TestWelch       = "welch"
+	TestMannWhitney = "mannwhitney"
+)
+
+// Compare returns the two-sided p-value of the selected test for the null
+// hypothesis that a and b come from the same distribution. ok is false when
+// either side has too few samples for the test to be meaningful.
+func Compare(test string, a, b []float64) (p float64, ok bool) {
+	if len(a) < 2 || len(b) < 2 {
+		return 0, false
+	}
+
+	switch test {
+	case TestMannWhitney:
+		return MannWhitneyU(a, b), true
+	default:
+		return WelchTTest(a, b), true
+	}
+}
+
+func WelchTTest(a, b []float64) float64 {
+	na, nb := float64(len(a)), float64(len(b))
+	va, vb := Variance(a)/na, Variance(b)/nb
+	diff := Mean(a) - Mean(b)
+
+	if va+vb == 0 {
+		if diff == 0 {
+			return 1
+		}
+		return 0
+	}
+
+	t := diff / math.Sqrt(va+vb)
+	df := (va + vb) * (va + vb) / (va*va/(na-1) + vb*vb/(nb-1))
+	return 2 * StudentTCDF(-math.Abs(t), df)
+}
+
+// MannWhitneyU uses the normal approximation with tie correction.
+func MannWhitneyU(a, b []float64) float64 {
+	type sample struct {
+		value float64
+		fromA bool
+	}
+
+	all := make([]sample, 0, len(a)+len(b))
+	for _, v := range a {
+		all = append(all, sample{v, true})
+	}
+	for _, v := range b {
+		all = append(all, sample{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	rankSumA := 0.0
+	tieTerm := 0.0
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromA {
+				rankSumA += rank
+			}
+		}
+		ties := float64(j - i)
+		tieTerm += ties*ties*ties - ties
+		i = j
+	}
+
+	na, nb := float64(len(a)), float64(len(b))
+	n := na + nb
+	u := rankSumA - na*(na+1)/2
+	mean := na * nb / 2
+	variance := na * nb / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	z = max(z, 0)
+	return math.Erfc(z / math.Sqrt2)
+}
@@ -0,0 +1,107 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWelchTTest(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{
+			// The two worked examples of the Welch's t-test Wikipedia article
+			// (t = -2.46, df = 24.99 and t = -1.57, df = 9.90).
+			name: "equal sizes",
+			a:    []float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4},
+			b:    []float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4},
+			want: 0.021378001,
+		},
+		{
+			name: "unequal sizes",
+			a:    []float64{17.2, 20.9, 22.6, 18.1, 21.7, 21.4, 23.5, 24.2, 14.7, 21.8},
+			b:    []float64{21.5, 22.8, 21.0, 23.0, 21.6, 23.6, 22.5, 20.7, 23.4, 21.8, 20.7, 21.7, 21.5, 22.5, 23.6, 21.5, 22.5, 23.5, 21.5, 21.8},
+			want: 0.148841697,
+		},
+		{
+			name: "identical constants",
+			a:    []float64{5, 5, 5},
+			b:    []float64{5, 5},
+			want: 1,
+		},
+		{
+			name: "different constants",
+			a:    []float64{5, 5, 5},
+			b:    []float64{6, 6},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WelchTTest(tt.a, tt.b); math.Abs(got-tt.want) > 1e-8 {
+				t.Errorf("WelchTTest = %.10f, want %.10f", got, tt.want)
+			}
+			if got := WelchTTest(tt.b, tt.a); math.Abs(got-tt.want) > 1e-8 {
+				t.Errorf("WelchTTest swapped = %.10f, want %.10f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	// Normal approximation with continuity and tie correction, as R's
+	// wilcox.test(exact = FALSE, correct = TRUE) computes it.
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{
+			// The wilcox.test example of R's documentation, U = 35.
+			name: "no ties",
+			a:    []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46},
+			b:    []float64{1.15, 0.88, 0.90, 0.74, 1.21},
+			want: 0.244623605,
+		},
+		{
+			name: "ties",
+			a:    []float64{1, 2, 2, 3, 3, 3, 4},
+			b:    []float64{3, 4, 4, 5, 5, 6, 7, 7},
+			want: 0.004793349,
+		},
+		{
+			name: "identical",
+			a:    []float64{1, 2, 3, 4},
+			b:    []float64{1, 2, 3, 4},
+			want: 1,
+		},
+		{
+			name: "all tied",
+			a:    []float64{2, 2, 2},
+			b:    []float64{2, 2},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MannWhitneyU(tt.a, tt.b); math.Abs(got-tt.want) > 1e-8 {
+				t.Errorf("MannWhitneyU = %.10f, want %.10f", got, tt.want)
+			}
+			if got := MannWhitneyU(tt.b, tt.a); math.Abs(got-tt.want) > 1e-8 {
+				t.Errorf("MannWhitneyU swapped = %.10f, want %.10f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareNeedsTwoSamples(t *testing.T) {
+	for _, test := range []string{TestWelch, TestMannWhitney} {
+		if _, ok := Compare(test, []float64{1}, []float64{1, 2}); ok {
+			t.Errorf("%s: a single sample should not be compared", test)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+type Summary struct {
+	Samples  []float64 `json:"samples"`
+	Mean     float64   `json:"mean"`
+	Median   float64   `json:"median"`
+	StdDev   float64   `json:"stddev"`
+	CI95Low  float64   `json:"ci95_low"`
+	CI95High float64   `json:"ci95_high"`
+}
+
+func Summarize(samples []float64) Summary {
+	summary := Summary{
+		Samples: samples,
+		Mean:    Mean(samples),
+		Median:  Median(samples),
+		StdDev:  StdDev(samples),
+	}
+	summary.CI95Low, summary.CI95High = ConfidenceInterval(samples, 0.95)
+	return summary
+}
+
+func Mean(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, v := range samples {
+		sum += v
+	}
+	return sum / float64(len(samples))
+}
+
+func Median(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// Variance is the unbiased sample variance.
+func Variance(samples []float64) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+
+	mean := Mean(samples)
+	sum := 0.0
+	for _, v := range samples {
+		sum += (v - mean) * (v - mean)
+	}
+	return sum / float64(len(samples)-1)
+}
+
+func StdDev(samples []float64) float64 {
+	return math.Sqrt(Variance(samples))
+}
+
+// ConfidenceInterval returns the Student t interval around the sample mean.
+// With fewer than two samples the interval collapses onto the mean.
+func ConfidenceInterval(samples []float64, level float64) (float64, float64) {
+	mean := Mean(samples)
+	n := len(samples)
+	if n < 2 {
+		return mean, mean
+	}
+
+	t := StudentTQuantile(1-(1-level)/2, float64(n-1))
+	margin := t * StdDev(samples) / math.Sqrt(float64(n))
+	return mean - margin, mean + margin
+}
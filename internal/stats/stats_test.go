@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name                 string
+		samples              []float64
+		mean, median, stddev float64
+		ci95Low, ci95High    float64
+	}{
+		{
+			// t(0.975, 5) = 2.570582
+			name:    "six samples",
+			samples: []float64{12.1, 11.8, 12.6, 12.0, 11.5, 12.3},
+			mean:    12.05,
+			median:  12.05,
+			stddev:  0.383405790,
+			ci95Low: 11.647640273, ci95High: 12.452359727,
+		},
+		{
+			// t(0.975, 2) = 4.302653, stddev 1
+			name:    "three samples",
+			samples: []float64{1, 2, 3},
+			mean:    2,
+			median:  2,
+			stddev:  1,
+			ci95Low: 2 - 4.302653/math.Sqrt(3), ci95High: 2 + 4.302653/math.Sqrt(3),
+		},
+		{
+			name:    "single sample",
+			samples: []float64{7},
+			mean:    7,
+			median:  7,
+			ci95Low: 7, ci95High: 7,
+		},
+		{
+			name:    "empty",
+			samples: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Summarize(tt.samples)
+			for _, c := range []struct {
+				field     string
+				got, want float64
+			}{
+				{"mean", s.Mean, tt.mean},
+				{"median", s.Median, tt.median},
+				{"stddev", s.StdDev, tt.stddev},
+				{"ci95 low", s.CI95Low, tt.ci95Low},
+				{"ci95 high", s.CI95High, tt.ci95High},
+			} {
+				if math.Abs(c.got-c.want) > 1e-6 {
+					t.Errorf("%s = %.9f, want %.9f", c.field, c.got, c.want)
+				}
+			}
+		})
+	}
+}
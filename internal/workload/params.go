@@ -0,0 +1,101 @@
+package workload
+
+import (
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/nadmax/dbcompare/internal/generator"
+)
+
+const (
+	ParamRandomID  = "random_id"
+	ParamInt       = "int"
+	ParamFloat     = "float"
+	ParamString    = "string"
+	ParamBool      = "bool"
+	ParamChoice    = "choice"
+	ParamSequence  = "sequence"
+	ParamName      = "name"
+	ParamTimestamp = "timestamp"
+)
+
+// Param describes how to generate one bound argument of a query.
+type Param struct {
+	Name      string  `yaml:"name"`
+	Type      string  `yaml:"type"`
+	Min       float64 `yaml:"min"`
+	Max       float64 `yaml:"max"`
+	MinLength int     `yaml:"min_length"`
+	MaxLength int     `yaml:"max_length"`
+	Values    []any   `yaml:"values"`
+
+	sequence atomic.Int64
+}
+
+func (p *Param) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("parameter without a name")
+	}
+
+	switch p.Type {
+	case ParamRandomID, ParamInt, ParamFloat, ParamBool, ParamName, ParamTimestamp:
+	case ParamSequence:
+		p.reset()
+	case ParamString:
+		if p.MaxLength == 0 {
+			p.MaxLength = max(p.MinLength, 16)
+		}
+	case ParamChoice:
+		if len(p.Values) == 0 {
+			return fmt.Errorf("parameter %q: choice needs values", p.Name)
+		}
+	default:
+		return fmt.Errorf("parameter %q: unknown type %q", p.Name, p.Type)
+	}
+
+	return nil
+}
+
+// reset restarts a sequence at min.
+func (p *Param) reset() {
+	p.sequence.Store(int64(p.Min) - 1)
+}
+
+// Generate produces the next value. recordCount bounds random_id parameters
+// that do not set max.
+func (p *Param) Generate(gen *generator.Generator, recordCount int) any {
+	switch p.Type {
+	case ParamRandomID:
+		if p.Max > 0 {
+			return gen.GenerateRandomID(int(p.Max))
+		}
+		return gen.GenerateRandomID(recordCount)
+	case ParamInt:
+		return gen.GenerateInt(int(p.Min), int(p.Max))
+	case ParamFloat:
+		return gen.GenerateFloat(p.Min, p.Max)
+	case ParamString:
+		return gen.GenerateString(p.MinLength, p.MaxLength)
+	case ParamBool:
+		return gen.GenerateBool()
+	case ParamChoice:
+		return gen.Pick(p.Values)
+	case ParamSequence:
+		return p.sequence.Add(1)
+	case ParamName:
+		return gen.GenerateUpdateValue("name")
+	case ParamTimestamp:
+		return gen.GenerateTimestamp()
+	default:
+		return nil
+	}
+}
+
+func (o *Operation) Args(gen *generator.Generator, recordCount int) []sql.NamedArg {
+	args := make([]sql.NamedArg, len(o.Params))
+	for i := range o.Params {
+		args[i] = sql.Named(o.Params[i].Name, o.Params[i].Generate(gen, recordCount))
+	}
+	return args
+}
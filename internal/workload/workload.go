@@ -0,0 +1,148 @@
+package workload
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Workload is a user-defined set of named operations loaded from YAML. Each
+// operation carries one query per engine, keyed by the driver's registration
+// name (postgres, surrealdb, mysql, sqlite, oracle).
+type Workload struct {
+	Name        string              `yaml:"name"`
+	LoadDataset bool                `yaml:"load_dataset"`
+	Setup       map[string][]string `yaml:"setup"`
+	Operations  []Operation         `yaml:"operations"`
+	Mix         *Mix                `yaml:"mix"`
+}
+
+type Operation struct {
+	Name        string            `yaml:"name"`
+	Queries     map[string]string `yaml:"queries"`
+	Params      []Param           `yaml:"params"`
+	Weight      float64           `yaml:"weight"`
+	Count       int               `yaml:"count"`
+	Duration    time.Duration     `yaml:"duration"`
+	Concurrency int               `yaml:"concurrency"`
+}
+
+// Mix interleaves every operation with a positive weight in a single step,
+// picking each call's operation with probability proportional to its weight.
+type Mix struct {
+	Name        string        `yaml:"name"`
+	Count       int           `yaml:"count"`
+	Duration    time.Duration `yaml:"duration"`
+	Concurrency int           `yaml:"concurrency"`
+}
+
+func Load(path string) (*Workload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workload file: %w", err)
+	}
+
+	var w Workload
+	if err := yaml.Unmarshal(data, &w); err != nil {
+		return nil, fmt.Errorf("failed to parse workload file: %w", err)
+	}
+
+	if err := w.validate(); err != nil {
+		return nil, fmt.Errorf("invalid workload %q: %w", path, err)
+	}
+
+	return &w, nil
+}
+
+func (w *Workload) validate() error {
+	if w.Name == "" {
+		w.Name = "Custom Workload"
+	}
+	if len(w.Operations) == 0 {
+		return fmt.Errorf("no operations defined")
+	}
+
+	seen := make(map[string]bool)
+	totalWeight := 0.0
+	for i := range w.Operations {
+		op := &w.Operations[i]
+		if op.Name == "" {
+			return fmt.Errorf("operation #%d has no name", i+1)
+		}
+		if seen[op.Name] {
+			return fmt.Errorf("operation %q defined twice", op.Name)
+		}
+		seen[op.Name] = true
+
+		if len(op.Queries) == 0 {
+			return fmt.Errorf("operation %q has no queries", op.Name)
+		}
+		if op.Weight < 0 {
+			return fmt.Errorf("operation %q has a negative weight", op.Name)
+		}
+		if op.Concurrency <= 0 {
+			op.Concurrency = 1
+		}
+		for j := range op.Params {
+			if err := op.Params[j].validate(); err != nil {
+				return fmt.Errorf("operation %q: %w", op.Name, err)
+			}
+		}
+		totalWeight += op.Weight
+	}
+
+	if w.Mix != nil {
+		if totalWeight == 0 {
+			return fmt.Errorf("mix requires at least one operation with a positive weight")
+		}
+		if w.Mix.Name == "" {
+			w.Mix.Name = "Mixed"
+		}
+		if w.Mix.Concurrency <= 0 {
+			w.Mix.Concurrency = 1
+		}
+	}
+
+	return nil
+}
+
+// CheckEngines rejects a mix with a weighted operation that has no query for
+// one of the engines: the mix would be renormalized over the remaining
+// operations and the engines would run different mixes under the same name.
+func (w *Workload) CheckEngines(engines []string) error {
+	if w.Mix == nil {
+		return nil
+	}
+
+	for _, op := range w.Operations {
+		if op.Weight <= 0 {
+			continue
+		}
+		for _, engine := range engines {
+			if _, ok := op.Queries[engine]; !ok {
+				return fmt.Errorf("mix %q: operation %q has no query for %s", w.Mix.Name, op.Name, engine)
+			}
+		}
+	}
+	return nil
+}
+
+// Reset restarts every sequence parameter at its min, so each engine run
+// draws the same values.
+func (w *Workload) Reset() {
+	for i := range w.Operations {
+		for j := range w.Operations[i].Params {
+			if w.Operations[i].Params[j].Type == ParamSequence {
+				w.Operations[i].Params[j].reset()
+			}
+		}
+	}
+}
+
+// Standalone reports whether the operation runs as its own step, which is the
+// case when it sets a count or a duration.
+func (o *Operation) Standalone() bool {
+	return o.Count > 0 || o.Duration > 0
+}